@@ -0,0 +1,141 @@
+// Package cache keeps the lessons fetched from the API on disk, one
+// JSON file per lesson, so that they can be revalidated instead of
+// downloaded again and so that lessons can be run offline.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/fsutil"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/xdg"
+)
+
+// Lessons is a directory of cached lessons. It implements api.LessonCache.
+type Lessons struct {
+	dir string
+}
+
+// Entry is a cached lesson along with its UUID.
+type Entry struct {
+	UUID string
+	api.CachedLesson
+}
+
+// New keeps lessons in dir.
+func New(dir string) *Lessons {
+	return &Lessons{dir: dir}
+}
+
+// Open keeps lessons in the lessons directory of the user's cache dir.
+func Open() (*Lessons, error) {
+	dir, err := xdg.CacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return New(filepath.Join(dir, "lessons")), nil
+}
+
+// Dir is where the lessons are kept.
+func (l *Lessons) Dir() string {
+	return l.dir
+}
+
+func (l *Lessons) path(uuid string) (string, error) {
+	// the UUID ends up in a file name, so it mustn't be able to leave the dir
+	if !fsutil.ValidName(uuid) {
+		return "", errors.New(i18n.T("cache.invalid_uuid", uuid))
+	}
+	return filepath.Join(l.dir, uuid+".json"), nil
+}
+
+// Get returns the cached lesson, or nil if it isn't cached.
+func (l *Lessons) Get(uuid string) (*api.CachedLesson, error) {
+	path, err := l.path(uuid)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var lesson api.CachedLesson
+	if err := json.Unmarshal(data, &lesson); err != nil {
+		return nil, err
+	}
+	return &lesson, nil
+}
+
+// Put caches a lesson, replacing any older copy.
+func (l *Lessons) Put(uuid string, lesson api.CachedLesson) error {
+	path, err := l.path(uuid)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(lesson)
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(path, data, 0o600)
+}
+
+// List returns every cached lesson, most recently fetched first.
+func (l *Lessons) List() ([]Entry, error) {
+	files, err := os.ReadDir(l.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entries := []Entry{}
+	for _, file := range files {
+		uuid, ok := strings.CutSuffix(file.Name(), ".json")
+		if !ok || file.IsDir() {
+			continue
+		}
+		lesson, err := l.Get(uuid)
+		if err != nil || lesson == nil {
+			continue
+		}
+		entries = append(entries, Entry{UUID: uuid, CachedLesson: *lesson})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].FetchedAt.After(entries[j].FetchedAt)
+	})
+	return entries, nil
+}
+
+// Clear removes every cached lesson and returns how many there were.
+func (l *Lessons) Clear() (int, error) {
+	files, err := os.ReadDir(l.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" && filepath.Ext(file.Name()) != ".tmp" {
+			continue
+		}
+		if err := os.Remove(filepath.Join(l.dir, file.Name())); err != nil {
+			return removed, err
+		}
+		if filepath.Ext(file.Name()) == ".json" {
+			removed++
+		}
+	}
+	return removed, nil
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/bootdotdev/bootdev/client"
+)
+
+func TestLessons(t *testing.T) {
+	lessons := New(t.TempDir())
+
+	if lesson, err := lessons.Get("abc-123"); err != nil || lesson != nil {
+		t.Fatalf("Expected nothing cached, got %v, %v", lesson, err)
+	}
+
+	older := api.CachedLesson{ETag: `"a"`, FetchedAt: time.Now().Add(-time.Hour), Data: []byte(`{"a":1}`)}
+	newer := api.CachedLesson{ETag: `"b"`, FetchedAt: time.Now(), Data: []byte(`{"b":2}`)}
+	if err := lessons.Put("abc-123", older); err != nil {
+		t.Fatal(err)
+	}
+	if err := lessons.Put("def-456", newer); err != nil {
+		t.Fatal(err)
+	}
+
+	lesson, err := lessons.Get("abc-123")
+	if err != nil || lesson == nil {
+		t.Fatalf("Expected a cached lesson, got %v, %v", lesson, err)
+	}
+	if lesson.ETag != `"a"` || string(lesson.Data) != `{"a":1}` {
+		t.Errorf("Expected the lesson as it was put, got %+v", lesson)
+	}
+
+	entries, err := lessons.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].UUID != "def-456" {
+		t.Errorf("Expected 2 entries, newest first, got %+v", entries)
+	}
+
+	removed, err := lessons.Clear()
+	if err != nil || removed != 2 {
+		t.Errorf("Expected 2 removed, got %d, %v", removed, err)
+	}
+	if entries, _ := lessons.List(); len(entries) != 0 {
+		t.Errorf("Expected an empty cache, got %+v", entries)
+	}
+}
+
+func TestLessons_RejectsPaths(t *testing.T) {
+	lessons := New(t.TempDir())
+	for _, uuid := range []string{"", "../config", "a/b", "a.json"} {
+		if err := lessons.Put(uuid, api.CachedLesson{}); err == nil {
+			t.Errorf("Expected an error for %q", uuid)
+		}
+	}
+}
@@ -2,16 +2,52 @@ package checks
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 
 	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
 )
 
+// The output of commands that are simulated or refused. It's sent to the
+// API for grading, so it's the same in every locale and only translated
+// when it's shown, with TranslateOutput.
+const (
+	simulatedLs       = "Simulated output for 'ls'"
+	simulatedCat      = "Simulated output for 'cat'"
+	invalidCommand    = "Invalid command"
+	commandNotAllowed = "Command not allowed"
+	invalidArguments  = "Invalid arguments"
+)
+
+type message struct {
+	key  string
+	args []any
+}
+
+var outputMessages = map[string]message{
+	simulatedLs:       {"checks.simulated_output", []any{"ls"}},
+	simulatedCat:      {"checks.simulated_output", []any{"cat"}},
+	invalidCommand:    {"checks.invalid_command", nil},
+	commandNotAllowed: {"checks.command_not_allowed", nil},
+	invalidArguments:  {"checks.invalid_arguments", nil},
+}
+
+// TranslateOutput returns the output of a command the way it's shown to
+// the user, with the output of simulated and refused commands translated.
+func TranslateOutput(stdout string) string {
+	if m, ok := outputMessages[stdout]; ok {
+		return i18n.T(m.key, m.args...)
+	}
+	return stdout
+}
+
 // Define internal command functions
 func executeLs(args []string) (string, int) {
 	// Implement logic for the 'ls' command
 	// For demonstration purposes, just return a static message
-	return "Simulated output for 'ls'", 0
+	return simulatedLs, 0
 }
 
 func executeEcho(args []string) (string, int) {
@@ -22,7 +58,7 @@ func executeEcho(args []string) (string, int) {
 func executeCat(args []string) (string, int) {
 	// Implement logic for the 'cat' command
 	// For demonstration purposes, just return a static message
-	return "Simulated output for 'cat'", 0
+	return simulatedCat, 0
 }
 
 // Map allowed commands to their corresponding functions
@@ -51,33 +87,47 @@ func CLICommand(
 		finalCommand := interpolateArgs(command.Command, optionalPositionalArgs)
 		responses[i].FinalCommand = finalCommand
 
+		// Check if the command is allowed and execute it
 		cmd, args := parseCommand(finalCommand)
-		if cmd == "" {
+		if violation := policyViolation(cmd, args); violation != "" {
 			responses[i].ExitCode = -1
-			responses[i].Stdout = "Invalid command"
+			responses[i].Stdout = violation
 			continue
 		}
 
-		// Check if the command is allowed and execute it
-		if handler, ok := commandHandlers[cmd]; ok {
-			if !validArgs(args, allowedArgs[cmd]) {
-				responses[i].ExitCode = -1
-				responses[i].Stdout = "Invalid arguments"
-				continue
-			}
-
-			output, exitCode := handler(args)
-			responses[i].ExitCode = exitCode
-			responses[i].Stdout = output
-		} else {
-			responses[i].ExitCode = -1
-			responses[i].Stdout = "Command not allowed"
-		}
+		output, exitCode := commandHandlers[cmd](args)
+		responses[i].ExitCode = exitCode
+		responses[i].Stdout = output
 	}
 
 	return responses
 }
 
+// CommandPolicy returns why a command would be rejected, or "" if it
+// would run. Positional arguments like $1 are only known when the
+// command runs, so they aren't checked.
+func CommandPolicy(command string) string {
+	cmd, args := parseCommand(command)
+	args = slices.DeleteFunc(args, positionalArg.MatchString)
+	return TranslateOutput(policyViolation(cmd, args))
+}
+
+var positionalArg = regexp.MustCompile(`^\$\d+$`)
+
+// policyViolation returns why a command may not run, or "" if it may
+func policyViolation(cmd string, args []string) string {
+	if cmd == "" {
+		return invalidCommand
+	}
+	if _, ok := commandHandlers[cmd]; !ok {
+		return commandNotAllowed
+	}
+	if !validArgs(args, allowedArgs[cmd]) {
+		return invalidArguments
+	}
+	return ""
+}
+
 // parseCommand splits a command string into command and arguments
 func parseCommand(command string) (string, []string) {
 	parts := strings.Fields(command)
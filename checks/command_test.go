@@ -0,0 +1,41 @@
+package checks
+
+import (
+	"encoding/json"
+	"testing"
+
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+)
+
+func TestCLICommandOutputIgnoresLocale(t *testing.T) {
+	defer i18n.SetLocale(i18n.Locale())
+	i18n.SetLocale("de")
+
+	var lesson api.Lesson
+	err := json.Unmarshal([]byte(`{"Lesson": {"Type": "type_cli_command", "LessonDataCLICommand": {"CLICommandData": {"Commands": [
+		{"Command": "ls -l"},
+		{"Command": "cat notes.txt"},
+		{"Command": "rm -rf /"},
+		{"Command": "ls -R"}
+	]}}}}`), &lesson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := CLICommand(lesson, nil)
+
+	// what's sent to the API is the same in every locale
+	want := []string{"Simulated output for 'ls'", "Simulated output for 'cat'", "Command not allowed", "Invalid arguments"}
+	for i, result := range results {
+		if result.Stdout != want[i] {
+			t.Errorf("command %d: stdout %q, want %q", i+1, result.Stdout, want[i])
+		}
+	}
+	// and only translated when it's shown
+	if got := TranslateOutput(results[0].Stdout); got != "Simulierte Ausgabe für 'ls'" {
+		t.Errorf("TranslateOutput() = %q, want the German message", got)
+	}
+	if got := TranslateOutput("hello"); got != "hello" {
+		t.Errorf("TranslateOutput() = %q, want real output untouched", got)
+	}
+}
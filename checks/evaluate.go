@@ -0,0 +1,148 @@
+package checks
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+)
+
+// EvaluateHTTPTests checks the results against the lesson's tests, like
+// the API does on submission, and returns the first test that failed.
+// It returns nil when all of them passed.
+func EvaluateHTTPTests(data api.LessonDataHTTPTests, results []HttpTestResult) *api.HTTPTestValidationError {
+	for i, request := range data.HttpTests.Requests {
+		if i >= len(results) {
+			return httpFailure(i, 0, i18n.T("checks.eval.no_result"))
+		}
+		result := results[i]
+		for j, test := range request.Tests {
+			if msg := EvaluateHTTPTest(test, result); msg != "" {
+				return httpFailure(i, j, msg)
+			}
+		}
+	}
+	return nil
+}
+
+func httpFailure(requestIndex int, testIndex int, msg string) *api.HTTPTestValidationError {
+	return &api.HTTPTestValidationError{
+		ErrorMessage:       &msg,
+		FailedRequestIndex: &requestIndex,
+		FailedTestIndex:    &testIndex,
+	}
+}
+
+// EvaluateHTTPTest returns why a single test failed, or "" if it passed.
+func EvaluateHTTPTest(test api.HTTPTest, result HttpTestResult) string {
+	switch {
+	case result.Err != "":
+		return result.Err
+	case test.StatusCode != nil:
+		if result.StatusCode != *test.StatusCode {
+			return i18n.T("checks.eval.status_code", *test.StatusCode, result.StatusCode)
+		}
+	case test.BodyContains != nil:
+		if !strings.Contains(result.BodyString, *test.BodyContains) {
+			return i18n.T("checks.eval.body_contains", *test.BodyContains)
+		}
+	case test.HeadersContain != nil:
+		key := http.CanonicalHeaderKey(test.HeadersContain.Key)
+		actual, ok := result.Headers[key]
+		if !ok {
+			return i18n.T("checks.eval.header_missing", key)
+		}
+		if !strings.Contains(strings.ToLower(actual), strings.ToLower(test.HeadersContain.Value)) {
+			return i18n.T("checks.eval.header_value", key, test.HeadersContain.Value, actual)
+		}
+	case test.JSONValue != nil:
+		return evaluateJSONValue(*test.JSONValue, result.BodyString)
+	}
+	return ""
+}
+
+func evaluateJSONValue(test api.HTTPTestJSONValue, body string) string {
+	actual, err := valFromJQPath(test.Path, body)
+	if err != nil {
+		return i18n.T("checks.eval.json_path", test.Path, err)
+	}
+	var expected any
+	var passed bool
+	switch {
+	case test.IntValue != nil:
+		expected = *test.IntValue
+		number, ok := actual.(float64)
+		switch test.Operator {
+		case api.OpGreaterThan:
+			passed = ok && number > float64(*test.IntValue)
+		default:
+			passed = ok && number == float64(*test.IntValue)
+		}
+	case test.StringValue != nil:
+		expected = *test.StringValue
+		passed = actual == *test.StringValue
+	case test.BoolValue != nil:
+		expected = *test.BoolValue
+		passed = actual == *test.BoolValue
+	}
+	if passed {
+		return ""
+	}
+	if test.Operator == api.OpGreaterThan {
+		expected = fmt.Sprintf("> %v", expected)
+	}
+	return i18n.T("checks.eval.json_value", test.Path, expected, actual)
+}
+
+// EvaluateCLICommand checks the results against the lesson's tests, like
+// the API does on submission, and returns the first test that failed.
+// It returns nil when all of them passed.
+func EvaluateCLICommand(data api.LessonDataCLICommand, results []api.CLICommandResult) *api.StructuredErrCLICommand {
+	for i, command := range data.CLICommandData.Commands {
+		if i >= len(results) {
+			return &api.StructuredErrCLICommand{ErrorMessage: i18n.T("checks.eval.no_result"), FailedCommandIndex: i}
+		}
+		for j, test := range command.Tests {
+			if msg := EvaluateCLITest(test, results[i]); msg != "" {
+				return &api.StructuredErrCLICommand{ErrorMessage: msg, FailedCommandIndex: i, FailedTestIndex: j}
+			}
+		}
+	}
+	return nil
+}
+
+// EvaluateCLITest returns why a single test failed, or "" if it passed.
+func EvaluateCLITest(test api.CLICommandTestCase, result api.CLICommandResult) string {
+	switch {
+	case test.ExitCode != nil:
+		if result.ExitCode != *test.ExitCode {
+			return i18n.T("checks.eval.exit_code", *test.ExitCode, result.ExitCode)
+		}
+	case test.StdoutContainsAll != nil:
+		for _, expected := range test.StdoutContainsAll {
+			if !strings.Contains(result.Stdout, expected) {
+				return i18n.T("checks.eval.stdout_contains", expected)
+			}
+		}
+	case test.StdoutContainsNone != nil:
+		for _, forbidden := range test.StdoutContainsNone {
+			if strings.Contains(result.Stdout, forbidden) {
+				return i18n.T("checks.eval.stdout_contains_none", forbidden)
+			}
+		}
+	case test.StdoutMatches != nil:
+		re, err := regexp.Compile(*test.StdoutMatches)
+		if err != nil || !re.MatchString(result.Stdout) {
+			return i18n.T("checks.eval.stdout_matches", *test.StdoutMatches)
+		}
+	case test.StdoutLinesGt != nil:
+		lines := len(strings.Split(strings.TrimSuffix(result.Stdout, "\n"), "\n"))
+		if lines <= *test.StdoutLinesGt {
+			return i18n.T("checks.eval.stdout_lines", *test.StdoutLinesGt, lines)
+		}
+	}
+	return ""
+}
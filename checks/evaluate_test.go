@@ -0,0 +1,103 @@
+package checks
+
+import (
+	"encoding/json"
+	"testing"
+
+	api "github.com/bootdotdev/bootdev/client"
+)
+
+func TestEvaluateHTTPTests(t *testing.T) {
+	var data api.LessonDataHTTPTests
+	err := json.Unmarshal([]byte(`{"HttpTests": {"Requests": [
+		{"Request": {"Method": "GET", "Path": "/"}, "Tests": [{"StatusCode": 200}]},
+		{"Request": {"Method": "GET", "Path": "/users"}, "Tests": [
+			{"HeadersContain": {"Key": "content-type", "Value": "JSON"}},
+			{"JSONValue": {"Path": ".count", "Operator": "gt", "IntValue": 1}},
+			{"JSONValue": {"Path": ".name", "Operator": "eq", "StringValue": "boots"}}
+		]}
+	]}}`), &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	passing := []HttpTestResult{
+		{StatusCode: 200},
+		{
+			StatusCode: 200,
+			Headers:    map[string]string{"Content-Type": "application/json"},
+			BodyString: `{"count": 2, "name": "boots"}`,
+		},
+	}
+	if failure := EvaluateHTTPTests(data, passing); failure != nil {
+		t.Fatalf("Expected all tests to pass, got %s", *failure.ErrorMessage)
+	}
+
+	tests := []struct {
+		name    string
+		results []HttpTestResult
+		request int
+		test    int
+	}{
+		{"status code", []HttpTestResult{{StatusCode: 404}, passing[1]}, 0, 0},
+		{"missing result", passing[:1], 1, 0},
+		{"header", []HttpTestResult{passing[0], {Headers: map[string]string{"Content-Type": "text/plain"}}}, 1, 0},
+		{"gt", []HttpTestResult{passing[0], {Headers: passing[1].Headers, BodyString: `{"count": 1}`}}, 1, 1},
+		{"string", []HttpTestResult{passing[0], {Headers: passing[1].Headers, BodyString: `{"count": 2, "name": "bob"}`}}, 1, 2},
+		{"error", []HttpTestResult{{Err: "Failed to fetch"}, passing[1]}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failure := EvaluateHTTPTests(data, tt.results)
+			if failure == nil {
+				t.Fatal("Expected a failure")
+			}
+			if *failure.FailedRequestIndex != tt.request || *failure.FailedTestIndex != tt.test {
+				t.Errorf("Expected request %d test %d to fail, got request %d test %d: %s",
+					tt.request, tt.test, *failure.FailedRequestIndex, *failure.FailedTestIndex, *failure.ErrorMessage)
+			}
+		})
+	}
+}
+
+func TestEvaluateCLICommand(t *testing.T) {
+	var data api.LessonDataCLICommand
+	err := json.Unmarshal([]byte(`{"CLICommandData": {"Commands": [
+		{"Command": "go version", "Tests": [
+			{"ExitCode": 0},
+			{"StdoutContainsAll": ["go version"]},
+			{"StdoutContainsNone": ["devel"]},
+			{"StdoutMatches": "go1\\.\\d+"},
+			{"StdoutLinesGt": 0}
+		]}
+	]}}`), &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	passing := api.CLICommandResult{Stdout: "go version go1.22.1 linux/amd64\n"}
+	if failure := EvaluateCLICommand(data, []api.CLICommandResult{passing}); failure != nil {
+		t.Fatalf("Expected all tests to pass, got %s", failure.ErrorMessage)
+	}
+
+	tests := []struct {
+		name   string
+		result api.CLICommandResult
+		test   int
+	}{
+		{"exit code", api.CLICommandResult{ExitCode: 1, Stdout: passing.Stdout}, 0},
+		{"contains all", api.CLICommandResult{Stdout: "go1.22.1"}, 1},
+		{"contains none", api.CLICommandResult{Stdout: "go version devel go1.23"}, 2},
+		{"matches", api.CLICommandResult{Stdout: "go version"}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failure := EvaluateCLICommand(data, []api.CLICommandResult{tt.result})
+			if failure == nil {
+				t.Fatal("Expected a failure")
+			}
+			if failure.FailedTestIndex != tt.test {
+				t.Errorf("Expected test %d to fail, got %d: %s", tt.test, failure.FailedTestIndex, failure.ErrorMessage)
+			}
+		})
+	}
+}
@@ -12,16 +12,31 @@ import (
 	"time"
 
 	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
 	"github.com/itchyny/gojq"
 	"github.com/spf13/cobra"
 )
 
 type HttpTestResult struct {
 	Err            string      `json:"-"`
+	RequestMethod  string      `json:"-"`
+	RequestURL     string      `json:"-"`
 	RequestHeaders http.Header `json:"-"`
-	StatusCode     int
-	Headers        map[string]string
-	BodyString     string
+	RequestBody    string      `json:"-"`
+	// ServerLogs is what the server logged while handling the request,
+	// if it was started by the CLI.
+	ServerLogs string `json:"-"`
+	StatusCode int
+	Headers    map[string]string
+	BodyString string
+}
+
+// Logs is the output of the server under test.
+type Logs interface {
+	// Len is how much has been logged so far.
+	Len() int
+	// Since returns what has been logged after the first n bytes.
+	Since(n int) string
 }
 
 func HttpTest(
@@ -30,6 +45,19 @@ func HttpTest(
 ) (
 	responses []HttpTestResult,
 	finalBaseURL string,
+) {
+	return HttpTestWithLogs(lesson, baseURL, nil)
+}
+
+// HttpTestWithLogs is HttpTest against a server whose logs are known,
+// which are kept with the result of the request they were logged for.
+func HttpTestWithLogs(
+	lesson api.Lesson,
+	baseURL *string,
+	logs Logs,
+) (
+	responses []HttpTestResult,
+	finalBaseURL string,
 ) {
 	data := lesson.Lesson.LessonDataHTTPTests
 	client := &http.Client{}
@@ -41,27 +69,29 @@ func HttpTest(
 		} else if data.HttpTests.BaseURL != nil {
 			finalBaseURL = *data.HttpTests.BaseURL
 		} else {
-			cobra.CheckErr("no base URL provided")
+			cobra.CheckErr(i18n.T("checks.no_base_url"))
 		}
 		finalBaseURL = strings.TrimSuffix(finalBaseURL, "/")
 
 		var r *http.Request
+		var requestBody string
 		if request.Request.BodyJSON != nil {
 			dat, err := json.Marshal(request.Request.BodyJSON)
 			if err != nil {
 				cobra.CheckErr(err)
 			}
+			requestBody = string(dat)
 			r, err = http.NewRequest(request.Request.Method, fmt.Sprintf("%s%s",
 				finalBaseURL, request.Request.Path), bytes.NewBuffer(dat))
 			if err != nil {
-				cobra.CheckErr("Failed to create request")
+				cobra.CheckErr(i18n.T("checks.create_request_error"))
 			}
 		} else {
 			var err error
 			r, err = http.NewRequest(request.Request.Method, fmt.Sprintf("%s%s",
 				finalBaseURL, request.Request.Path), nil)
 			if err != nil {
-				cobra.CheckErr("Failed to create request")
+				cobra.CheckErr(i18n.T("checks.create_request_error"))
 			}
 		}
 
@@ -77,31 +107,42 @@ func HttpTest(
 			time.Sleep(time.Duration(*request.Request.Actions.DelayRequestByMs) * time.Millisecond)
 		}
 
+		responses[i] = HttpTestResult{
+			RequestMethod:  r.Method,
+			RequestURL:     r.URL.String(),
+			RequestHeaders: r.Header,
+			RequestBody:    requestBody,
+		}
+
+		logged := 0
+		if logs != nil {
+			logged = logs.Len()
+		}
 		resp, err := client.Do(r)
+		if logs != nil {
+			responses[i].ServerLogs = logs.Since(logged)
+		}
 		if err != nil {
-			responses[i] = HttpTestResult{Err: "Failed to fetch"}
+			responses[i].Err = i18n.T("checks.fetch_error")
 			continue
 		}
 		defer resp.Body.Close()
 
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			responses[i] = HttpTestResult{Err: "Failed to read response body"}
+			responses[i].Err = i18n.T("checks.read_body_error")
 			continue
 		}
 		headers := make(map[string]string)
 		for k, v := range resp.Header {
 			headers[k] = strings.Join(v, ",")
 		}
-		responses[i] = HttpTestResult{
-			RequestHeaders: r.Header,
-			StatusCode:     resp.StatusCode,
-			Headers:        headers,
-			BodyString:     string(body),
-		}
+		responses[i].StatusCode = resp.StatusCode
+		responses[i].Headers = headers
+		responses[i].BodyString = string(body)
 
 		if err := parseVariables(body, request.ResponseVariables, variables); err != nil {
-			responses[i].Err = fmt.Sprintf("Failed to parse variables: %v", err)
+			responses[i].Err = i18n.T("checks.parse_variables_error", err)
 		}
 	}
 	return responses, finalBaseURL
@@ -124,11 +165,11 @@ func valFromJQPath(path string, jsn string) (any, error) {
 		return nil, err
 	}
 	if len(vals) != 1 {
-		return nil, errors.New("invalid number of values found")
+		return nil, errors.New(i18n.T("checks.jq_value_count"))
 	}
 	val := vals[0]
 	if val == nil {
-		return nil, errors.New("value not found")
+		return nil, errors.New(i18n.T("checks.jq_value_not_found"))
 	}
 	return val, nil
 }
@@ -162,9 +203,19 @@ func valsFromJQPath(path string, jsn string) ([]any, error) {
 	return vals, nil
 }
 
+var variablePattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// VariableNames returns the names of the ${variables} in a template.
+func VariableNames(template string) []string {
+	names := []string{}
+	for _, match := range variablePattern.FindAllStringSubmatch(template, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
 func interpolateVariables(template string, vars map[string]string) string {
-	r := regexp.MustCompile(`\$\{([^}]+)\}`)
-	return r.ReplaceAllStringFunc(template, func(m string) string {
+	return variablePattern.ReplaceAllStringFunc(template, func(m string) string {
 		// Extract the key from the match, which is in the form ${key}
 		key := strings.TrimSuffix(strings.TrimPrefix(m, "${"), "}")
 		if val, ok := vars[key]; ok {
@@ -0,0 +1,41 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	api "github.com/bootdotdev/bootdev/client"
+)
+
+type fakeLogs struct {
+	strings.Builder
+}
+
+func (l *fakeLogs) Since(n int) string {
+	return l.String()[n:]
+}
+
+func TestHttpTestWithLogs(t *testing.T) {
+	logs := &fakeLogs{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(logs, "handled %s", r.URL.Path)
+	}))
+	defer srv.Close()
+
+	var lesson api.Lesson
+	err := json.Unmarshal([]byte(`{"Lesson": {"Type": "type_http_tests", "LessonDataHTTPTests": {"HttpTests": {"Requests": [
+		{"Request": {"Method": "GET", "Path": "/a"}},
+		{"Request": {"Method": "GET", "Path": "/b"}}
+	]}}}}`), &lesson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, _ := HttpTestWithLogs(lesson, &srv.URL, logs)
+	if len(results) != 2 || results[0].ServerLogs != "handled /a" || results[1].ServerLogs != "handled /b" {
+		t.Errorf("Expected each request's own logs, got %+v", results)
+	}
+}
@@ -1,14 +1,11 @@
 package api
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
-
-	"github.com/spf13/viper"
 )
 
 type LoginRequest struct {
@@ -20,57 +17,66 @@ type LoginResponse struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
-func FetchAccessToken() (*LoginResponse, error) {
-	api_url := viper.GetString("api_url")
-	client := &http.Client{}
-	r, err := http.NewRequest("POST", api_url+"/v1/auth/refresh", bytes.NewBuffer([]byte{}))
-	r.Header.Add("X-Refresh-Token", viper.GetString("refresh_token"))
+// FetchAccessToken exchanges the refresh token for a new pair of tokens.
+func (c *Client) FetchAccessToken(ctx context.Context) (*LoginResponse, error) {
+	token, err := c.tokens.Token()
 	if err != nil {
 		return nil, err
 	}
-	resp, err := client.Do(r)
+	r, err := c.newRequest(ctx, "POST", "/v1/auth/refresh", []byte{})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, errors.New("invalid refresh token")
-	}
+	r.Header.Add("X-Refresh-Token", token.RefreshToken)
 
-	body, err := io.ReadAll(resp.Body)
+	body, code, err := c.do(r)
 	if err != nil {
 		return nil, err
 	}
+	if code != 200 {
+		return nil, errors.New("invalid refresh token")
+	}
 
 	var creds LoginResponse
 	err = json.Unmarshal(body, &creds)
 	return &creds, err
 }
 
-func LoginWithCode(code string) (*LoginResponse, error) {
-	api_url := viper.GetString("api_url")
+func (c *Client) refreshToken(ctx context.Context) (Token, error) {
+	creds, err := c.FetchAccessToken(ctx)
+	if err != nil {
+		return Token{}, err
+	}
+	if creds.AccessToken == "" || creds.RefreshToken == "" {
+		return Token{}, errors.New("invalid refresh token")
+	}
+	return Token{AccessToken: creds.AccessToken, RefreshToken: creds.RefreshToken}, nil
+}
+
+// LoginWithCode exchanges a one-time login code from the website for tokens.
+func (c *Client) LoginWithCode(ctx context.Context, code string) (*LoginResponse, error) {
 	req, err := json.Marshal(LoginRequest{Otp: code})
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := http.Post(api_url+"/v1/auth/otp/login", "application/json", bytes.NewReader(req))
+	r, err := c.newRequest(ctx, "POST", "/v1/auth/otp/login", req)
 	if err != nil {
 		return nil, err
 	}
+	r.Header.Set("Content-Type", "application/json")
 
-	if resp.StatusCode == 403 {
-		return nil, errors.New("the code you entered was invalid. try refreshing your browser and trying again")
+	body, status, err := c.do(r)
+	if err != nil {
+		return nil, err
 	}
 
-	if resp.StatusCode != 200 {
-		return nil, errors.New(resp.Status)
+	if status == 403 {
+		return nil, errors.New("the code you entered was invalid. try refreshing your browser and trying again")
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	if status != 200 {
+		return nil, fmt.Errorf("%d %s", status, http.StatusText(status))
 	}
 
 	var creds LoginResponse
@@ -82,36 +88,77 @@ func LoginWithCode(code string) (*LoginResponse, error) {
 	return &creds, nil
 }
 
-func fetchWithAuth(method string, url string) ([]byte, error) {
-	body, code, err := fetchWithAuthAndPayload(method, url, []byte{})
+// Logout revokes the refresh token.
+func (c *Client) Logout(ctx context.Context) error {
+	token, err := c.tokens.Token()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if code != 200 {
-		return nil, fmt.Errorf("failed to %s to %s\nResponse: %d %s", method, url, code, string(body))
+	r, err := c.newRequest(ctx, "POST", "/v1/auth/logout", []byte{})
+	if err != nil {
+		return err
 	}
-	return body, err
+	r.Header.Add("X-Refresh-Token", token.RefreshToken)
+
+	_, code, err := c.do(r)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK {
+		return fmt.Errorf("logout failed with status code: %d", code)
+	}
+	return nil
+}
+
+func (c *Client) fetchWithAuth(ctx context.Context, method string, url string) ([]byte, error) {
+	return c.fetchWithAuthAndPayload(ctx, method, url, []byte{})
 }
 
-func fetchWithAuthAndPayload(method string, url string, payload []byte) ([]byte, int, error) {
-	api_url := viper.GetString("api_url")
-	client := &http.Client{}
-	r, err := http.NewRequest(method, api_url+url, bytes.NewBuffer(payload))
+// fetchWithAuthAndPayload sends an authenticated request. Any status
+// but 2xx is returned as an *APIError.
+func (c *Client) fetchWithAuthAndPayload(ctx context.Context, method string, url string, payload []byte) ([]byte, error) {
+	r, err := c.newAuthRequest(ctx, method, url, payload)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
-	r.Header.Add("Authorization", "Bearer "+viper.GetString("access_token"))
+	return c.fetch(r)
+}
 
-	resp, err := client.Do(r)
+// submitWithAuth posts a submission. It carries an idempotency key, so
+// it's safe to resend when the response got lost.
+func (c *Client) submitWithAuth(ctx context.Context, url string, payload []byte) ([]byte, error) {
+	r, err := c.newAuthRequest(ctx, "POST", url, payload)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	key, ok := ctx.Value(idempotencyKeyContextKey{}).(string)
+	if !ok {
+		key = NewIdempotencyKey()
 	}
-	defer resp.Body.Close()
+	r.Header.Set("Idempotency-Key", key)
+	return c.fetch(r)
+}
 
-	body, err := io.ReadAll(resp.Body)
+func (c *Client) newAuthRequest(ctx context.Context, method string, url string, payload []byte) (*http.Request, error) {
+	token, err := c.tokens.Token()
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	r, err := c.newRequest(ctx, method, url, payload)
+	if err != nil {
+		return nil, err
 	}
+	r.Header.Add("Authorization", "Bearer "+token.AccessToken)
+	return r, nil
+}
 
-	return body, resp.StatusCode, nil
+func (c *Client) fetch(r *http.Request) ([]byte, error) {
+	resp, body, err := c.send(r)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, newAPIError(r, resp, body)
+	}
+	return body, nil
 }
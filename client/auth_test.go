@@ -1,12 +1,11 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
-
-	"github.com/spf13/viper"
 )
 
 func TestFetchAccessToken_Success(t *testing.T) {
@@ -24,10 +23,12 @@ func TestFetchAccessToken_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	viper.Set("api_url", server.URL)
-	viper.Set("refresh_token", "mockRefreshToken")
+	client := New(
+		WithBaseURL(server.URL),
+		WithTokenSource(StaticTokenSource(Token{RefreshToken: "mockRefreshToken"})),
+	)
 
-	resp, err := FetchAccessToken()
+	resp, err := client.FetchAccessToken(context.Background())
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -52,10 +53,12 @@ func TestFetchAccessToken_InvalidToken(t *testing.T) {
 	}))
 	defer server.Close()
 
-	viper.Set("api_url", server.URL)
-	viper.Set("refresh_token", "mockRefreshToken")
+	client := New(
+		WithBaseURL(server.URL),
+		WithTokenSource(StaticTokenSource(Token{RefreshToken: "mockRefreshToken"})),
+	)
 
-	resp, err := FetchAccessToken()
+	resp, err := client.FetchAccessToken(context.Background())
 
 	if err == nil || err.Error() != "invalid refresh token" {
 		t.Errorf("Expected error 'invalid refresh token', got %v", err)
@@ -67,10 +70,12 @@ func TestFetchAccessToken_InvalidToken(t *testing.T) {
 }
 
 func TestFetchAccessToken_RequestError(t *testing.T) {
-	viper.Set("api_url", "http://invalid-url")
-	viper.Set("refresh_token", "mockRefreshToken")
+	client := New(
+		WithBaseURL("http://invalid-url"),
+		WithTokenSource(StaticTokenSource(Token{RefreshToken: "mockRefreshToken"})),
+	)
 
-	resp, err := FetchAccessToken()
+	resp, err := client.FetchAccessToken(context.Background())
 
 	if err == nil {
 		t.Errorf("Expected an error, got none")
@@ -80,3 +85,48 @@ func TestFetchAccessToken_RequestError(t *testing.T) {
 		t.Errorf("Expected no response, got %v", resp)
 	}
 }
+
+func TestFetchLesson_SendsCredentialsAndUserAgent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/static/lessons/some-uuid" {
+			t.Errorf("Expected path '/v1/static/lessons/some-uuid', got %v", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer mockAccessToken" {
+			t.Errorf("Expected bearer token 'mockAccessToken', got %v", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("User-Agent") != "bootdev-cli/test" {
+			t.Errorf("Expected user agent 'bootdev-cli/test', got %v", r.Header.Get("User-Agent"))
+		}
+		w.Write([]byte(`{"Lesson": {"Type": "type_cli_command"}}`))
+	}))
+	defer server.Close()
+
+	client := New(
+		WithBaseURL(server.URL),
+		WithTokenSource(StaticTokenSource(Token{AccessToken: "mockAccessToken"})),
+		WithUserAgent("bootdev-cli/test"),
+	)
+
+	lesson, err := client.FetchLesson(context.Background(), "some-uuid")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if lesson.Lesson.Type != "type_cli_command" {
+		t.Errorf("Expected lesson type 'type_cli_command', got %v", lesson.Lesson.Type)
+	}
+}
+
+func TestFetchLesson_CanceledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no request to be sent")
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := New(WithBaseURL(server.URL)).FetchLesson(ctx, "some-uuid")
+	if err == nil {
+		t.Error("Expected an error, got none")
+	}
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrNotCached is returned when a lesson is needed offline but was
+// never fetched.
+var ErrNotCached = errors.New("lesson not cached")
+
+// CachedLesson is the raw data of a lesson as the API returned it, along
+// with what's needed to ask the API whether it changed since.
+type CachedLesson struct {
+	ETag         string          `json:"etag,omitempty"`
+	LastModified string          `json:"last_modified,omitempty"`
+	FetchedAt    time.Time       `json:"fetched_at"`
+	Data         json.RawMessage `json:"data"`
+}
+
+// LessonCache keeps fetched lessons between runs.
+type LessonCache interface {
+	// Get returns nil without an error for lessons that aren't cached.
+	Get(uuid string) (*CachedLesson, error)
+	Put(uuid string, lesson CachedLesson) error
+}
+
+// WithLessonCache makes FetchLesson keep lessons in cache and only
+// download them again when they changed. A cached lesson is also used
+// when the API can't be reached.
+func WithLessonCache(cache LessonCache) Option {
+	return func(c *Client) {
+		c.lessons = cache
+	}
+}
+
+// CachedLesson returns a lesson from the cache without going online.
+func (c *Client) CachedLesson(uuid string) (*Lesson, error) {
+	if c.lessons == nil {
+		return nil, ErrNotCached
+	}
+	cached, err := c.lessons.Get(uuid)
+	if err != nil {
+		return nil, err
+	}
+	if cached == nil {
+		return nil, ErrNotCached
+	}
+	return parseLesson(cached.Data)
+}
+
+// revalidateLesson fetches a lesson unless the cached copy is still
+// current. The cache is best effort: a broken entry is fetched again
+// and a failure to save one doesn't fail the fetch.
+func (c *Client) revalidateLesson(ctx context.Context, uuid string) ([]byte, error) {
+	cached, err := c.lessons.Get(uuid)
+	if err != nil {
+		cached = nil
+	}
+	r, err := c.newAuthRequest(ctx, "GET", "/v1/static/lessons/"+uuid, []byte{})
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil && cached.ETag != "" {
+		r.Header.Set("If-None-Match", cached.ETag)
+	}
+	if cached != nil && cached.LastModified != "" {
+		r.Header.Set("If-Modified-Since", cached.LastModified)
+	}
+
+	resp, body, err := c.send(r)
+	if err != nil {
+		if cached != nil && errors.Is(err, ErrNetwork) {
+			return cached.Data, nil
+		}
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		cached.FetchedAt = time.Now()
+		c.lessons.Put(uuid, *cached)
+		return cached.Data, nil
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		c.lessons.Put(uuid, CachedLesson{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			FetchedAt:    time.Now(),
+			Data:         body,
+		})
+		return body, nil
+	}
+	return nil, newAPIError(r, resp, body)
+}
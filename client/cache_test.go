@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type memoryLessons map[string]CachedLesson
+
+func (m memoryLessons) Get(uuid string) (*CachedLesson, error) {
+	lesson, ok := m[uuid]
+	if !ok {
+		return nil, nil
+	}
+	return &lesson, nil
+}
+
+func (m memoryLessons) Put(uuid string, lesson CachedLesson) error {
+	m[uuid] = lesson
+	return nil
+}
+
+func TestFetchLesson_RevalidatesCachedCopy(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"Lesson": {"Type": "type_cli_command"}}`))
+	}))
+	defer server.Close()
+
+	lessons := memoryLessons{}
+	client := New(WithBaseURL(server.URL), WithLessonCache(lessons))
+	for i := 0; i < 2; i++ {
+		lesson, err := client.FetchLesson(context.Background(), "mockUUID")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if lesson.Lesson.Type != "type_cli_command" {
+			t.Errorf("Expected type 'type_cli_command', got %q", lesson.Lesson.Type)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+	if lessons["mockUUID"].ETag != `"v1"` {
+		t.Errorf("Expected the ETag to be cached, got %q", lessons["mockUUID"].ETag)
+	}
+}
+
+func TestFetchLesson_FallsBackToCacheWhenOffline(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	lessons := memoryLessons{"mockUUID": {Data: []byte(`{"Lesson": {"Type": "type_http_tests"}}`)}}
+	client := New(WithBaseURL(server.URL), WithLessonCache(lessons), WithRetries(1))
+	lesson, err := client.FetchLesson(context.Background(), "mockUUID")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if lesson.Lesson.Type != "type_http_tests" {
+		t.Errorf("Expected type 'type_http_tests', got %q", lesson.Lesson.Type)
+	}
+
+	if _, err := client.CachedLesson("otherUUID"); !errors.Is(err, ErrNotCached) {
+		t.Errorf("Expected ErrNotCached, got %v", err)
+	}
+}
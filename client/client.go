@@ -0,0 +1,156 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultBaseURL is the API the client talks to unless told otherwise.
+const DefaultBaseURL = "https://api.boot.dev"
+
+// Token holds the credentials of a logged in user.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// TokenSource supplies the credentials requests are authenticated with.
+// It's consulted on every request so that refreshed tokens are picked up.
+type TokenSource interface {
+	Token() (Token, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource.
+type TokenSourceFunc func() (Token, error)
+
+func (f TokenSourceFunc) Token() (Token, error) {
+	return f()
+}
+
+// StaticTokenSource always returns the same credentials.
+func StaticTokenSource(token Token) TokenSource {
+	return TokenSourceFunc(func() (Token, error) {
+		return token, nil
+	})
+}
+
+// Client talks to the boot.dev API. Create one with New.
+type Client struct {
+	baseURL    string
+	tokens     TokenSource
+	store      TokenStore
+	userAgent  string
+	httpClient *http.Client
+
+	maxAttempts int
+	notifyRetry RetryNotifier
+	lessons     LessonCache
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL sets the URL of the API, e.g. https://api.boot.dev.
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.baseURL = url
+	}
+}
+
+// WithTokenSource sets where the client gets its credentials from.
+func WithTokenSource(tokens TokenSource) Option {
+	return func(c *Client) {
+		c.tokens = tokens
+	}
+}
+
+// WithTransport sets the RoundTripper used to send requests.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient.Transport = transport
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout limits how long a single request may take, including
+// reading the response body. Zero means no limit.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// New creates a client. Without options it talks to DefaultBaseURL
+// anonymously using http.DefaultTransport, retrying failed idempotent
+// requests up to DefaultMaxAttempts times.
+func New(opts ...Option) *Client {
+	c := &Client{
+		baseURL:    DefaultBaseURL,
+		tokens:     StaticTokenSource(Token{}),
+		httpClient: &http.Client{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.httpClient.Transport = &RetryingTransport{
+		Base:        c.httpClient.Transport,
+		MaxAttempts: c.maxAttempts,
+		Notify:      c.notifyRetry,
+	}
+	if c.store != nil {
+		c.httpClient.Transport = &RefreshingTransport{
+			Base:    c.httpClient.Transport,
+			Refresh: c.refreshToken,
+			Store:   c.store,
+		}
+	}
+	return c
+}
+
+func (c *Client) newRequest(ctx context.Context, method string, path string, payload []byte) (*http.Request, error) {
+	r, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	if c.userAgent != "" {
+		r.Header.Set("User-Agent", c.userAgent)
+	}
+	return r, nil
+}
+
+// do sends the request and reads the whole response body.
+func (c *Client) do(r *http.Request) ([]byte, int, error) {
+	resp, body, err := c.send(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return body, resp.StatusCode, nil
+}
+
+// send is do for callers that need the response headers. Failing to get
+// a response at all is a NetworkError, unless the context ended.
+func (c *Client) send(r *http.Request) (*http.Response, []byte, error) {
+	resp, err := c.httpClient.Do(r)
+	if err != nil {
+		if ctxErr := r.Context().Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		return nil, nil, &NetworkError{Err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, &NetworkError{Err: err}
+	}
+	return resp, body, nil
+}
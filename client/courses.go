@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// Course is a course as listed for the user, with their progress in it.
+type Course struct {
+	UUID                string
+	Slug                string
+	Title               string
+	NumLessons          int
+	NumCompletedLessons int
+}
+
+// FetchCourses lists the courses there are.
+func (c *Client) FetchCourses(ctx context.Context) ([]Course, error) {
+	resp, err := c.fetchWithAuth(ctx, "GET", "/v1/courses")
+	if err != nil {
+		return nil, err
+	}
+	var courses []Course
+	if err := json.Unmarshal(resp, &courses); err != nil {
+		return nil, err
+	}
+	return courses, nil
+}
+
+type courseProgress struct {
+	CompletedLessonUUIDs []string
+}
+
+// FetchCourseProgress returns the lessons of a course the user completed,
+// by their UUIDs.
+func (c *Client) FetchCourseProgress(ctx context.Context, course string) (map[string]bool, error) {
+	resp, err := c.fetchWithAuth(ctx, "GET", "/v1/courses/"+url.PathEscape(course)+"/progress")
+	if err != nil {
+		return nil, err
+	}
+	var progress courseProgress
+	if err := json.Unmarshal(resp, &progress); err != nil {
+		return nil, err
+	}
+	completed := make(map[string]bool, len(progress.CompletedLessonUUIDs))
+	for _, uuid := range progress.CompletedLessonUUIDs {
+		completed[uuid] = true
+	}
+	return completed, nil
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchCourseProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/courses/learn-http/progress" {
+			t.Errorf("Expected path '/v1/courses/learn-http/progress', got %v", r.URL.Path)
+		}
+		w.Write([]byte(`{"CompletedLessonUUIDs": ["a", "b"]}`))
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL), WithTokenSource(StaticTokenSource(Token{AccessToken: "mockAccessToken"})))
+
+	completed, err := client.FetchCourseProgress(context.Background(), "learn-http")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(completed) != 2 || !completed["a"] || !completed["b"] || completed["c"] {
+		t.Errorf("Expected lessons a and b to be completed, got %v", completed)
+	}
+}
+
+func TestFetchLessonInfoBySlug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/v1/lessons/slug/odd%2Fslug" {
+			t.Errorf("Expected the slug to be escaped, got %v", r.URL.EscapedPath())
+		}
+		w.Write([]byte(`{"UUID": "some-uuid", "Slug": "odd/slug", "Type": "type_http_tests", "Completed": true}`))
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL), WithTokenSource(StaticTokenSource(Token{AccessToken: "mockAccessToken"})))
+
+	info, err := client.FetchLessonInfoBySlug(context.Background(), "odd/slug")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if info.UUID != "some-uuid" || !info.Completed {
+		t.Errorf("Unexpected lesson info: %+v", info)
+	}
+}
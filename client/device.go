@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DeviceCode is what the API hands out to start a device login. The
+// user enters UserCode at VerificationURI on any device while the CLI
+// polls with DeviceCode.
+type DeviceCode struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	// seconds until the codes expire
+	ExpiresIn int `json:"expires_in"`
+	// minimum seconds between polls
+	Interval int `json:"interval"`
+}
+
+// Errors returned while polling for a device login.
+var (
+	ErrAuthorizationPending = errors.New("authorization pending")
+	ErrSlowDown             = errors.New("polling too fast")
+	ErrDeviceCodeExpired    = errors.New("the device code expired, please try again")
+	ErrAccessDenied         = errors.New("the login was denied")
+)
+
+// defaultDeviceInterval is used when the API doesn't say how often to poll.
+const defaultDeviceInterval = 5 * time.Second
+
+type deviceTokenRequest struct {
+	DeviceCode string `json:"device_code"`
+}
+
+type deviceTokenError struct {
+	Error string `json:"error"`
+}
+
+// RequestDeviceCode starts a device login.
+func (c *Client) RequestDeviceCode(ctx context.Context) (*DeviceCode, error) {
+	r, err := c.newRequest(ctx, "POST", "/v1/auth/device/code", []byte{})
+	if err != nil {
+		return nil, err
+	}
+	body, code, err := c.do(r)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("failed to start device login. code: %v: %s", code, string(body))
+	}
+	var deviceCode DeviceCode
+	if err := json.Unmarshal(body, &deviceCode); err != nil {
+		return nil, err
+	}
+	return &deviceCode, nil
+}
+
+// PollDeviceToken asks once whether the device login was approved. It
+// returns ErrAuthorizationPending or ErrSlowDown while the user hasn't
+// approved it yet.
+func (c *Client) PollDeviceToken(ctx context.Context, deviceCode string) (*LoginResponse, error) {
+	req, err := json.Marshal(deviceTokenRequest{DeviceCode: deviceCode})
+	if err != nil {
+		return nil, err
+	}
+	r, err := c.newRequest(ctx, "POST", "/v1/auth/device/token", req)
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Content-Type", "application/json")
+	body, code, err := c.do(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if code != http.StatusOK {
+		var failure deviceTokenError
+		json.Unmarshal(body, &failure)
+		switch failure.Error {
+		case "authorization_pending":
+			return nil, ErrAuthorizationPending
+		case "slow_down":
+			return nil, ErrSlowDown
+		case "expired_token":
+			return nil, ErrDeviceCodeExpired
+		case "access_denied":
+			return nil, ErrAccessDenied
+		}
+		return nil, fmt.Errorf("failed to poll device login. code: %v: %s", code, string(body))
+	}
+
+	var creds LoginResponse
+	if err := json.Unmarshal(body, &creds); err != nil {
+		return nil, err
+	}
+	return &creds, nil
+}
+
+// WaitForDeviceToken polls until the device login is approved, denied
+// or expired, or ctx is done, backing off whenever the API asks to.
+func (c *Client) WaitForDeviceToken(ctx context.Context, deviceCode *DeviceCode) (*LoginResponse, error) {
+	interval := time.Duration(deviceCode.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDeviceInterval
+	}
+	if deviceCode.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(deviceCode.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, ErrDeviceCodeExpired
+			}
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		creds, err := c.PollDeviceToken(ctx, deviceCode.DeviceCode)
+		switch {
+		case errors.Is(err, ErrAuthorizationPending):
+		case errors.Is(err, ErrSlowDown):
+			interval += 5 * time.Second
+		case err != nil && ctx.Err() != nil:
+			// the deadline hit mid-request; report it on the next iteration
+		default:
+			return creds, err
+		}
+	}
+}
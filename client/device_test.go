@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWaitForDeviceToken_PollsUntilApproved(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req deviceTokenRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.DeviceCode != "mockDeviceCode" {
+			t.Errorf("Expected device code 'mockDeviceCode', got %v", req.DeviceCode)
+		}
+		if polls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "authorization_pending"}`))
+			return
+		}
+		response, _ := json.Marshal(LoginResponse{AccessToken: "mockAccessToken", RefreshToken: "mockRefreshToken"})
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL))
+	creds, err := client.WaitForDeviceToken(context.Background(), &DeviceCode{DeviceCode: "mockDeviceCode", Interval: 1, ExpiresIn: 30})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if creds.AccessToken != "mockAccessToken" {
+		t.Errorf("Expected access token 'mockAccessToken', got %v", creds.AccessToken)
+	}
+	if polls.Load() != 2 {
+		t.Errorf("Expected 2 polls, got %d", polls.Load())
+	}
+}
+
+func TestWaitForDeviceToken_Denied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "access_denied"}`))
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL))
+	_, err := client.WaitForDeviceToken(context.Background(), &DeviceCode{DeviceCode: "mockDeviceCode", Interval: 1, ExpiresIn: 30})
+	if !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("Expected ErrAccessDenied, got %v", err)
+	}
+}
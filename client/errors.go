@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Errors that unsuccessful requests can be checked against with errors.Is.
+// Use errors.As with an *APIError to get at the status code, or the
+// RetryAfter of ErrRateLimited.
+var (
+	ErrUnauthorized = errors.New("not logged in or the session expired")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("too many requests")
+	ErrServer       = errors.New("the server failed to handle the request")
+	ErrNetwork      = errors.New("couldn't reach the server")
+)
+
+// APIError is an unsuccessful response from the API.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// Message is the error the API gave, if any
+	Message string
+	// RetryAfter is how long the API asked to wait before trying again,
+	// zero if it didn't say
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("failed to %s %s: %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= 500:
+		return ErrServer
+	}
+	return nil
+}
+
+// NetworkError is a request that never got a response.
+type NetworkError struct {
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return ErrNetwork.Error() + ": " + e.Err.Error()
+}
+
+func (e *NetworkError) Unwrap() []error {
+	return []error{ErrNetwork, e.Err}
+}
+
+// newAPIError builds the error for an unsuccessful response.
+func newAPIError(r *http.Request, resp *http.Response, body []byte) *APIError {
+	return &APIError{
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		StatusCode: resp.StatusCode,
+		Message:    errorMessage(body),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// errorMessage pulls the message out of an error response, which is
+// usually JSON like {"error": "..."} but can be anything a proxy in
+// between came up with.
+func errorMessage(body []byte) string {
+	var parsed struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &parsed) == nil {
+		if parsed.Error != "" {
+			return parsed.Error
+		}
+		if parsed.Message != "" {
+			return parsed.Message
+		}
+	}
+	msg := strings.TrimSpace(string(body))
+	if strings.HasPrefix(msg, "<") {
+		// an HTML error page says nothing the status doesn't
+		return ""
+	}
+	if len(msg) > 200 {
+		// cut at the start of a character, not in the middle of one
+		cut := 200
+		for cut > 0 && !utf8.RuneStart(msg[cut]) {
+			cut--
+		}
+		msg = msg[:cut] + "..."
+	}
+	return msg
+}
+
+// parseRetryAfter reads a Retry-After header, which holds either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return max(0, time.Duration(seconds)*time.Second)
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return max(0, at.Sub(now))
+	}
+	return 0
+}
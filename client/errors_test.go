@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestFetchLesson_TypedErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		header string
+		body   string
+		want   error
+	}{
+		{status: http.StatusUnauthorized, want: ErrUnauthorized},
+		{status: http.StatusNotFound, body: `{"error": "lesson not found"}`, want: ErrNotFound},
+		{status: http.StatusTooManyRequests, header: "30", want: ErrRateLimited},
+		{status: http.StatusBadGateway, body: "<html>bad gateway</html>", want: ErrServer},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if tt.header != "" {
+				w.Header().Set("Retry-After", tt.header)
+			}
+			w.WriteHeader(tt.status)
+			w.Write([]byte(tt.body))
+		}))
+
+		client := New(WithBaseURL(server.URL), WithTokenSource(StaticTokenSource(Token{AccessToken: "mockAccessToken"})), WithRetries(1))
+		_, err := client.FetchLesson(context.Background(), "mockUUID")
+		server.Close()
+
+		if !errors.Is(err, tt.want) {
+			t.Errorf("status %d: expected %v, got %v", tt.status, tt.want, err)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("status %d: expected an *APIError, got %T", tt.status, err)
+		}
+		if apiErr.StatusCode != tt.status {
+			t.Errorf("expected status %d, got %d", tt.status, apiErr.StatusCode)
+		}
+		if tt.status == http.StatusNotFound && apiErr.Message != "lesson not found" {
+			t.Errorf("expected the message from the body, got %q", apiErr.Message)
+		}
+		if tt.status == http.StatusBadGateway && apiErr.Message != "" {
+			t.Errorf("expected no message for an HTML page, got %q", apiErr.Message)
+		}
+		if tt.status == http.StatusTooManyRequests && apiErr.RetryAfter != 30*time.Second {
+			t.Errorf("expected to retry after 30s, got %v", apiErr.RetryAfter)
+		}
+	}
+}
+
+func TestFetchLesson_NetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := New(WithBaseURL(server.URL), WithTokenSource(StaticTokenSource(Token{AccessToken: "mockAccessToken"})), WithRetries(1))
+	_, err := client.FetchLesson(context.Background(), "mockUUID")
+	if !errors.Is(err, ErrNetwork) {
+		t.Errorf("Expected ErrNetwork, got %v", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"":                              0,
+		"120":                           2 * time.Minute,
+		"-5":                            0,
+		"Wed, 01 May 2024 12:00:45 GMT": 45 * time.Second,
+		"Wed, 01 May 2024 11:00:00 GMT": 0,
+		"soon":                          0,
+	}
+	for header, want := range tests {
+		if got := parseRetryAfter(header, now); got != want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
+
+func TestErrorMessageTruncation(t *testing.T) {
+	// 199 bytes and then a character of three, which the cut falls into
+	body := strings.Repeat("a", 199) + strings.Repeat("€", 10)
+	got := errorMessage([]byte(body))
+	if !utf8.ValidString(got) {
+		t.Errorf("errorMessage() cut a character in half: %q", got)
+	}
+	if want := strings.Repeat("a", 199) + "..."; got != want {
+		t.Errorf("errorMessage() = %q, want %q", got, want)
+	}
+}
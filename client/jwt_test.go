@@ -0,0 +1,32 @@
+package api
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestTokenExpiry(t *testing.T) {
+	jwt := func(payload string) string {
+		return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+	}
+
+	expiry, ok := TokenExpiry(jwt(`{"sub": "user", "exp": 1700000000}`))
+	if !ok {
+		t.Fatal("Expected an expiry, got none")
+	}
+	if !expiry.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Expected expiry %v, got %v", time.Unix(1700000000, 0), expiry)
+	}
+
+	for _, token := range []string{
+		"opaque-token",
+		jwt(`{"sub": "user"}`),
+		jwt(`not json`),
+		"a.!!!.c",
+	} {
+		if _, ok := TokenExpiry(token); ok {
+			t.Errorf("Expected no expiry for %q", token)
+		}
+	}
+}
@@ -1,8 +1,9 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
+	"net/url"
 )
 
 type ResponseVariable struct {
@@ -87,20 +88,85 @@ type Lesson struct {
 	}
 }
 
-func FetchLesson(uuid string) (*Lesson, error) {
-	resp, err := fetchWithAuth("GET", "/v1/static/lessons/"+uuid)
+func (c *Client) FetchLesson(ctx context.Context, uuid string) (*Lesson, error) {
+	var resp []byte
+	var err error
+	if c.lessons != nil {
+		resp, err = c.revalidateLesson(ctx, uuid)
+	} else {
+		resp, err = c.fetchWithAuth(ctx, "GET", "/v1/static/lessons/"+uuid)
+	}
 	if err != nil {
 		return nil, err
 	}
+	return parseLesson(resp)
+}
 
+func parseLesson(resp []byte) (*Lesson, error) {
 	var data Lesson
-	err = json.Unmarshal(resp, &data)
+	err := json.Unmarshal(resp, &data)
 	if err != nil {
 		return nil, err
 	}
 	return &data, nil
 }
 
+// CourseLesson is a lesson as listed in its course.
+type CourseLesson struct {
+	UUID  string
+	Slug  string
+	Title string
+	Type  string
+}
+
+// FetchCourseLessons lists the lessons of a course, in order.
+func (c *Client) FetchCourseLessons(ctx context.Context, course string) ([]CourseLesson, error) {
+	resp, err := c.fetchWithAuth(ctx, "GET", "/v1/static/courses/"+url.PathEscape(course)+"/lessons")
+	if err != nil {
+		return nil, err
+	}
+	var lessons []CourseLesson
+	if err := json.Unmarshal(resp, &lessons); err != nil {
+		return nil, err
+	}
+	return lessons, nil
+}
+
+// LessonInfo describes a lesson, apart from its tests, and whether the
+// user completed it.
+type LessonInfo struct {
+	UUID        string
+	Slug        string
+	Title       string
+	Type        string
+	CourseUUID  string
+	CourseTitle string
+	Completed   bool
+}
+
+// FetchLessonInfo describes the lesson with the UUID.
+func (c *Client) FetchLessonInfo(ctx context.Context, uuid string) (*LessonInfo, error) {
+	return c.fetchLessonInfo(ctx, "/v1/lessons/"+url.PathEscape(uuid))
+}
+
+// FetchLessonInfoBySlug describes the lesson with the slug, e.g. to find
+// its UUID.
+func (c *Client) FetchLessonInfoBySlug(ctx context.Context, slug string) (*LessonInfo, error) {
+	return c.fetchLessonInfo(ctx, "/v1/lessons/slug/"+url.PathEscape(slug))
+}
+
+func (c *Client) fetchLessonInfo(ctx context.Context, path string) (*LessonInfo, error) {
+	resp, err := c.fetchWithAuth(ctx, "GET", path)
+	if err != nil {
+		return nil, err
+	}
+	var info LessonInfo
+	if err := json.Unmarshal(resp, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
 type HTTPTestValidationError struct {
 	ErrorMessage       *string `json:"Error"`
 	FailedRequestIndex *int    `json:"FailedRequestIndex"`
@@ -111,18 +177,26 @@ type submitHTTPTestRequest struct {
 	ActualHTTPRequests any `json:"actualHTTPRequests"`
 }
 
-func SubmitHTTPTestLesson(uuid string, results any) (*HTTPTestValidationError, error) {
-	bytes, err := json.Marshal(submitHTTPTestRequest{ActualHTTPRequests: results})
+func (c *Client) SubmitHTTPTestLesson(ctx context.Context, uuid string, results any) (*HTTPTestValidationError, error) {
+	bytes, err := HTTPTestPayload(results)
 	if err != nil {
 		return nil, err
 	}
-	resp, code, err := fetchWithAuthAndPayload("POST", "/v1/lessons/"+uuid+"/http_tests", bytes)
+	return c.SubmitHTTPTestPayload(ctx, uuid, bytes)
+}
+
+// HTTPTestPayload is the body SubmitHTTPTestLesson sends for results.
+func HTTPTestPayload(results any) ([]byte, error) {
+	return json.Marshal(submitHTTPTestRequest{ActualHTTPRequests: results})
+}
+
+// SubmitHTTPTestPayload sends a body built by HTTPTestPayload, e.g. one
+// that was queued while offline.
+func (c *Client) SubmitHTTPTestPayload(ctx context.Context, uuid string, bytes []byte) (*HTTPTestValidationError, error) {
+	resp, err := c.submitWithAuth(ctx, "/v1/lessons/"+uuid+"/http_tests", bytes)
 	if err != nil {
 		return nil, err
 	}
-	if code != 200 {
-		return nil, fmt.Errorf("failed to submit HTTP tests. code: %v: %s", code, string(resp))
-	}
 	var failure HTTPTestValidationError
 	err = json.Unmarshal(resp, &failure)
 	if err != nil || failure.ErrorMessage == nil {
@@ -147,18 +221,26 @@ type CLICommandResult struct {
 	Stdout       string
 }
 
-func SubmitCLICommandLesson(uuid string, results []CLICommandResult) (*StructuredErrCLICommand, error) {
-	bytes, err := json.Marshal(submitCLICommandRequest{CLICommandResults: results})
+func (c *Client) SubmitCLICommandLesson(ctx context.Context, uuid string, results []CLICommandResult) (*StructuredErrCLICommand, error) {
+	bytes, err := CLICommandPayload(results)
 	if err != nil {
 		return nil, err
 	}
-	resp, code, err := fetchWithAuthAndPayload("POST", "/v1/lessons/"+uuid+"/cli_command", bytes)
+	return c.SubmitCLICommandPayload(ctx, uuid, bytes)
+}
+
+// CLICommandPayload is the body SubmitCLICommandLesson sends for results.
+func CLICommandPayload(results []CLICommandResult) ([]byte, error) {
+	return json.Marshal(submitCLICommandRequest{CLICommandResults: results})
+}
+
+// SubmitCLICommandPayload sends a body built by CLICommandPayload, e.g.
+// one that was queued while offline.
+func (c *Client) SubmitCLICommandPayload(ctx context.Context, uuid string, bytes []byte) (*StructuredErrCLICommand, error) {
+	resp, err := c.submitWithAuth(ctx, "/v1/lessons/"+uuid+"/cli_command", bytes)
 	if err != nil {
 		return nil, err
 	}
-	if code != 200 {
-		return nil, fmt.Errorf("failed to submit CLI command tests. code: %v: %s", code, string(resp))
-	}
 	var failure StructuredErrCLICommand
 	err = json.Unmarshal(resp, &failure)
 	if err != nil || failure.ErrorMessage == "" {
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// TokenStore is a TokenSource that can also persist refreshed tokens.
+type TokenStore interface {
+	TokenSource
+	SetToken(Token) error
+}
+
+// RefreshingTransport replays requests rejected with 401 Unauthorized
+// once, after exchanging the refresh token for new credentials.
+// Concurrent requests that hit a 401 share a single refresh.
+type RefreshingTransport struct {
+	// Base sends the requests. http.DefaultTransport is used when nil.
+	Base http.RoundTripper
+	// Refresh fetches new tokens from the API.
+	Refresh func(ctx context.Context) (Token, error)
+	// Store holds the current tokens and persists refreshed ones.
+	Store TokenStore
+
+	group singleflight.Group
+}
+
+func (t *RefreshingTransport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+func (t *RefreshingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base().RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	// Only requests authenticated with an access token can be saved by
+	// a refresh; a 401 from the refresh endpoint itself is final.
+	used, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return resp, nil
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+
+	token, err := t.refresh(req.Context(), used)
+	if err != nil {
+		return resp, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	retry.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	return t.base().RoundTrip(retry)
+}
+
+// refresh returns fresh tokens. If another request already refreshed
+// the access token that was rejected, its result is reused.
+func (t *RefreshingTransport) refresh(ctx context.Context, rejected string) (Token, error) {
+	v, err, _ := t.group.Do("refresh", func() (any, error) {
+		current, err := t.Store.Token()
+		if err != nil {
+			return Token{}, err
+		}
+		if current.AccessToken != "" && current.AccessToken != rejected {
+			return current, nil
+		}
+		token, err := t.Refresh(ctx)
+		if err != nil {
+			return Token{}, err
+		}
+		if err := t.Store.SetToken(token); err != nil {
+			return Token{}, err
+		}
+		return token, nil
+	})
+	return v.(Token), err
+}
+
+// WithTokenStore authenticates with the tokens in store, like
+// WithTokenSource, and transparently refreshes them when the API
+// rejects the access token. Refreshed tokens are saved to store.
+func WithTokenStore(store TokenStore) Option {
+	return func(c *Client) {
+		c.tokens = store
+		c.store = store
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type memoryTokens struct {
+	mu    sync.Mutex
+	token Token
+	saved int
+}
+
+func (m *memoryTokens) Token() (Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.token, nil
+}
+
+func (m *memoryTokens) SetToken(token Token) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.token = token
+	m.saved++
+	return nil
+}
+
+func newRefreshServer(t *testing.T, refreshes *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/refresh":
+			if r.Header.Get("X-Refresh-Token") != "oldRefreshToken" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			refreshes.Add(1)
+			response, _ := json.Marshal(LoginResponse{AccessToken: "newAccessToken", RefreshToken: "newRefreshToken"})
+			w.Write(response)
+		case "/v1/lessons/some-uuid/cli_command":
+			if r.Header.Get("Authorization") != "Bearer newAccessToken" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			if len(body) == 0 {
+				t.Error("Expected the replayed request to have a body")
+			}
+			w.Write([]byte(`{}`))
+		}
+	}))
+}
+
+func TestRefreshingTransport_ReplaysAfterRefresh(t *testing.T) {
+	var refreshes atomic.Int32
+	server := newRefreshServer(t, &refreshes)
+	defer server.Close()
+
+	store := &memoryTokens{token: Token{AccessToken: "oldAccessToken", RefreshToken: "oldRefreshToken"}}
+	client := New(WithBaseURL(server.URL), WithTokenStore(store))
+
+	failure, err := client.SubmitCLICommandLesson(context.Background(), "some-uuid", []CLICommandResult{{Stdout: "hi"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if failure != nil {
+		t.Errorf("Expected no failure, got %v", failure)
+	}
+	if store.token.AccessToken != "newAccessToken" || store.token.RefreshToken != "newRefreshToken" {
+		t.Errorf("Expected the new tokens to be stored, got %v", store.token)
+	}
+}
+
+func TestRefreshingTransport_RefreshesOnceForConcurrentRequests(t *testing.T) {
+	var refreshes atomic.Int32
+	server := newRefreshServer(t, &refreshes)
+	defer server.Close()
+
+	store := &memoryTokens{token: Token{AccessToken: "oldAccessToken", RefreshToken: "oldRefreshToken"}}
+	client := New(WithBaseURL(server.URL), WithTokenStore(store))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.SubmitCLICommandLesson(context.Background(), "some-uuid", []CLICommandResult{{Stdout: "hi"}})
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if refreshes.Load() != 1 {
+		t.Errorf("Expected 1 refresh, got %d", refreshes.Load())
+	}
+	if store.saved != 1 {
+		t.Errorf("Expected the tokens to be saved once, got %d", store.saved)
+	}
+}
+
+func TestRefreshingTransport_GivesUpWhenRefreshFails(t *testing.T) {
+	var refreshes atomic.Int32
+	server := newRefreshServer(t, &refreshes)
+	defer server.Close()
+
+	store := &memoryTokens{token: Token{AccessToken: "oldAccessToken", RefreshToken: "revokedRefreshToken"}}
+	client := New(WithBaseURL(server.URL), WithTokenStore(store))
+
+	_, err := client.SubmitCLICommandLesson(context.Background(), "some-uuid", []CLICommandResult{{Stdout: "hi"}})
+	if err == nil {
+		t.Error("Expected an error, got none")
+	}
+	if store.saved != 0 {
+		t.Errorf("Expected no tokens to be saved, got %d saves", store.saved)
+	}
+}
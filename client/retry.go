@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// DefaultMaxAttempts is how often a request is sent at most, unless
+// WithRetries says otherwise.
+const DefaultMaxAttempts = 3
+
+// Backoff between attempts starts at retryBaseDelay and doubles each
+// time up to retryMaxDelay. A Retry-After longer than retryMaxWait
+// isn't waited out, the error is returned instead.
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 10 * time.Second
+	retryMaxWait   = time.Minute
+)
+
+// RetryNotifier is told about every retry before waiting for it. err
+// describes why the last attempt failed.
+type RetryNotifier func(attempt int, wait time.Duration, err error)
+
+// RetryingTransport resends requests that hit a rate limit, a transient
+// server error or a network error. Only idempotent requests are resent:
+// those with an idempotent method and those carrying an Idempotency-Key.
+type RetryingTransport struct {
+	// Base sends the requests. http.DefaultTransport is used when nil.
+	Base http.RoundTripper
+	// MaxAttempts is how often a request is sent at most, including the
+	// first time. Values below one mean DefaultMaxAttempts.
+	MaxAttempts int
+	// Notify, if set, is called before waiting for a retry.
+	Notify RetryNotifier
+}
+
+func (t *RetryingTransport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+func (t *RetryingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	maxAttempts := t.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	if !retryable(req) {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base().RoundTrip(req)
+		if attempt >= maxAttempts || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		var wait time.Duration
+		var reason error
+		switch {
+		case err != nil:
+			reason = err
+			wait = backoff(attempt)
+		case shouldRetryStatus(resp.StatusCode):
+			reason = fmt.Errorf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+			wait = backoff(attempt)
+			if retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); retryAfter > 0 {
+				if retryAfter > retryMaxWait {
+					return resp, nil
+				}
+				wait = retryAfter
+			}
+		default:
+			return resp, nil
+		}
+
+		next := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			next.Body = body
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if t.Notify != nil {
+			t.Notify(attempt, wait, reason)
+		}
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		req = next
+	}
+}
+
+// retryable reports whether sending req again can't do any harm, and
+// whether its body can be sent again.
+func retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+func shouldRetryStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff is the exponential delay before the given retry, with half
+// of it jittered so that scripts running in parallel spread out.
+func backoff(attempt int) time.Duration {
+	delay := retryBaseDelay << (attempt - 1)
+	if delay <= 0 || delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey makes submissions sent with ctx use key, so that
+// sending one again later, e.g. from a queue, can't count it twice.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// NewIdempotencyKey returns a random key that lets the API recognize a
+// resent submission.
+func NewIdempotencyKey() string {
+	key := make([]byte, 16)
+	if _, err := cryptorand.Read(key); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(key)
+}
+
+// WithRetries sets how often a request is sent at most, including the
+// first attempt. One disables retries.
+func WithRetries(maxAttempts int) Option {
+	return func(c *Client) {
+		c.maxAttempts = maxAttempts
+	}
+}
+
+// WithRetryNotifier sets a function that's told about every retry,
+// e.g. to show that the CLI is waiting.
+func WithRetryNotifier(notify RetryNotifier) Option {
+	return func(c *Client) {
+		c.notifyRetry = notify
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var waited []time.Duration
+	client := New(
+		WithBaseURL(server.URL),
+		WithRetryNotifier(func(attempt int, wait time.Duration, err error) {
+			waited = append(waited, wait)
+		}),
+	)
+	if _, err := client.FetchLesson(context.Background(), "mockUUID"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests.Load())
+	}
+	if len(waited) != 1 || waited[0] != time.Second {
+		t.Errorf("Expected a single wait of 1s, got %v", waited)
+	}
+}
+
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL), WithRetries(2))
+	_, err := client.FetchLesson(context.Background(), "mockUUID")
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if requests.Load() != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests.Load())
+	}
+}
+
+func TestRetry_SubmissionsKeepTheirIdempotencyKey(t *testing.T) {
+	var keys []string
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(keys) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL))
+	if _, err := client.SubmitCLICommandLesson(context.Background(), "mockUUID", []CLICommandResult{{Stdout: "hi"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("Expected the same idempotency key on both attempts, got %q", keys)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] {
+		t.Errorf("Expected the body to be sent again, got %q", bodies)
+	}
+}
+
+func TestRetry_SkipsNonIdempotentRequests(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL))
+	client.RequestDeviceCode(context.Background())
+	if requests.Load() != 1 {
+		t.Errorf("Expected 1 request, got %d", requests.Load())
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 1; attempt < 70; attempt++ {
+		delay := retryBaseDelay << (attempt - 1)
+		if delay <= 0 || delay > retryMaxDelay {
+			delay = retryMaxDelay
+		}
+		got := backoff(attempt)
+		if got < delay/2 || got > delay {
+			t.Errorf("backoff(%d) = %v, want between %v and %v", attempt, got, delay/2, delay)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bootdotdev/bootdev/credentials"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/profile"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: i18n.T("auth.short"),
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:          "status",
+	Short:        i18n.T("auth.status.short"),
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token, err := configTokens{}.Token()
+		if err != nil {
+			return err
+		}
+		if token.AccessToken == "" && token.RefreshToken == "" {
+			fmt.Println(i18n.T("auth.status.logged_out"))
+			fmt.Println(i18n.T("root.login_prompt"))
+			return nil
+		}
+		fmt.Println(i18n.T("auth.status.logged_in", viper.GetString(profile.Key("api_url")), profile.Active()))
+		if tokensFromEnv() {
+			fmt.Println(i18n.T("auth.status.env"))
+		} else {
+			fmt.Println(i18n.T("auth.status.store", viper.GetString("credential_store")))
+		}
+		// like requireAuth, a refresh token alone is enough
+		if token.AccessToken == "" {
+			fmt.Println(i18n.T("auth.status.refresh_only"))
+			return nil
+		}
+
+		if expiry, ok := accessTokenExpiry(); ok {
+			remaining := time.Until(expiry).Round(time.Second)
+			at := expiry.Local().Format(time.DateTime)
+			if remaining > 0 {
+				fmt.Println(i18n.T("auth.status.expires_in", remaining, at))
+			} else {
+				fmt.Println(i18n.T("auth.status.expired", -remaining, at))
+			}
+			return nil
+		}
+		if tokensFromEnv() {
+			// nothing is known about when an opaque token was issued
+			return nil
+		}
+		lastRefresh := time.Unix(viper.GetInt64(profile.Key("last_refresh")), 0)
+		fmt.Println(i18n.T("auth.status.opaque", time.Since(lastRefresh).Round(time.Second), opaqueTokenRefreshAge))
+		return nil
+	},
+}
+
+var migrateCredentialsTo string
+
+var authMigrateCredentialsCmd = &cobra.Command{
+	Use:          "migrate-credentials",
+	Short:        i18n.T("auth.migrate.short"),
+	Long:         i18n.T("auth.migrate.long"),
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from := viper.GetString("credential_store")
+		if from == migrateCredentialsTo {
+			fmt.Println(i18n.T("auth.migrate.already", from))
+			return nil
+		}
+		if err := moveCredentials(migrateCredentialsTo); err != nil {
+			return err
+		}
+		fmt.Println(i18n.T("auth.migrate.success", from, migrateCredentialsTo))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authStatusCmd)
+	authCmd.AddCommand(authMigrateCredentialsCmd)
+	authMigrateCredentialsCmd.Flags().StringVar(&migrateCredentialsTo, "to", credentials.Keyring,
+		i18n.T("auth.migrate.flag.to", strings.Join(credentials.Backends(), ", ")))
+}
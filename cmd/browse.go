@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/lesson"
+	"github.com/bootdotdev/bootdev/render"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+var coursesCmd = &cobra.Command{
+	Use:          "courses",
+	Short:        i18n.T("browse.courses.short"),
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	PreRun:       compose(requireUpdated, requireAuth),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		courses, err := newClient().FetchCourses(cmd.Context())
+		if err != nil {
+			return explainAPIError(err)
+		}
+		if len(courses) == 0 {
+			fmt.Println(i18n.T("browse.courses.empty"))
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "%s\t%s\t%s\n", i18n.T("browse.column.title"), i18n.T("browse.column.slug"), i18n.T("browse.column.completed"))
+		for _, course := range courses {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", course.Title, course.Slug, courseProgress(course))
+		}
+		return w.Flush()
+	},
+}
+
+var lessonsCmd = &cobra.Command{
+	Use:          "lessons COURSE",
+	Short:        i18n.T("browse.lessons.short"),
+	Long:         i18n.T("browse.lessons.long"),
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	PreRun:       compose(requireUpdated, requireAuth),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lessons, completed, err := fetchCourseLessons(cmd.Context(), newClient(), args[0])
+		if err != nil {
+			return err
+		}
+		if len(lessons) == 0 {
+			fmt.Println(i18n.T("browse.lessons.empty"))
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "#\t%s\t%s\t%s\t%s\t%s\n", i18n.T("browse.column.title"), i18n.T("browse.column.type"),
+			i18n.T("browse.column.status"), i18n.T("browse.column.slug"), i18n.T("browse.column.uuid"))
+		for i, l := range lessons {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", i+1, l.Title, lessonTypeName(l.Type),
+				completionStatus(completed[l.UUID]), orDash(l.Slug), l.UUID)
+		}
+		return w.Flush()
+	},
+}
+
+var lessonShowCmd = &cobra.Command{
+	Use:          "show UUID|SLUG",
+	Short:        i18n.T("browse.show.short"),
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	PreRun:       compose(requireUpdated, requireAuth),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := newClient()
+		info, err := fetchLessonInfo(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "%s\t%s\n", i18n.T("browse.show.title"), info.Title)
+		fmt.Fprintf(w, "%s\t%s\n", i18n.T("browse.show.course"), orDash(info.CourseTitle))
+		fmt.Fprintf(w, "%s\t%s\n", i18n.T("browse.show.type"), lessonTypeName(info.Type))
+		fmt.Fprintf(w, "%s\t%s\n", i18n.T("browse.show.status"), completionStatus(info.Completed))
+		fmt.Fprintf(w, "%s\t%s\n", i18n.T("browse.show.slug"), orDash(info.Slug))
+		fmt.Fprintf(w, "%s\t%s\n", i18n.T("browse.show.uuid"), info.UUID)
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if info.Type != lesson.TypeHTTPTests && info.Type != lesson.TypeCLICommand {
+			return nil
+		}
+		lessonData, err := client.FetchLesson(cmd.Context(), info.UUID)
+		if err != nil {
+			return explainAPIError(err)
+		}
+		fmt.Println()
+		render.LessonOutline(*lessonData)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(coursesCmd)
+	rootCmd.AddCommand(lessonsCmd)
+	lessonCmd.AddCommand(lessonShowCmd)
+}
+
+func courseProgress(course api.Course) string {
+	if course.NumLessons > 0 && course.NumCompletedLessons >= course.NumLessons {
+		return completionStatus(true)
+	}
+	return i18n.T("browse.progress", course.NumCompletedLessons, course.NumLessons)
+}
+
+func completionStatus(completed bool) string {
+	if completed {
+		return i18n.T("browse.completed")
+	}
+	return i18n.T("browse.not_completed")
+}
+
+// lessonTypeName is how a lesson type is called on the website.
+func lessonTypeName(lessonType string) string {
+	switch lessonType {
+	case lesson.TypeHTTPTests:
+		return i18n.T("browse.type.http_tests")
+	case lesson.TypeCLICommand:
+		return i18n.T("browse.type.cli_command")
+	}
+	return strings.ReplaceAll(strings.TrimPrefix(lessonType, "type_"), "_", " ")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// fetchCourseLessons lists the lessons of a course by its UUID or slug,
+// along with the ones the user completed.
+func fetchCourseLessons(ctx context.Context, client *api.Client, course string) ([]api.CourseLesson, map[string]bool, error) {
+	lessons, err := client.FetchCourseLessons(ctx, course)
+	if errors.Is(err, api.ErrNotFound) {
+		return nil, nil, errors.New(i18n.T("browse.course_not_found", course))
+	}
+	if err != nil {
+		return nil, nil, explainAPIError(err)
+	}
+	completed, err := client.FetchCourseProgress(ctx, course)
+	if err != nil {
+		return nil, nil, explainAPIError(err)
+	}
+	return lessons, completed, nil
+}
+
+// fetchLessonInfo describes a lesson by its UUID or slug.
+func fetchLessonInfo(ctx context.Context, client *api.Client, lessonID string) (*api.LessonInfo, error) {
+	var info *api.LessonInfo
+	var err error
+	if uuidPattern.MatchString(lessonID) {
+		info, err = client.FetchLessonInfo(ctx, lessonID)
+	} else {
+		info, err = client.FetchLessonInfoBySlug(ctx, lessonID)
+	}
+	if errors.Is(err, api.ErrNotFound) {
+		return nil, errors.New(i18n.T("browse.lesson_not_found", lessonID))
+	}
+	if err != nil {
+		return nil, explainAPIError(err)
+	}
+	return info, nil
+}
+
+// resolveLesson makes sure the first arg is the UUID of a lesson. A slug
+// is looked up, and without any the user picks a lesson of a course.
+func resolveLesson(cmd *cobra.Command, args []string) ([]string, error) {
+	if len(args) > 0 && uuidPattern.MatchString(args[0]) {
+		return args, nil
+	}
+	if offline {
+		return nil, errors.New(i18n.T("browse.offline_uuid"))
+	}
+	if len(args) > 0 {
+		info, err := fetchLessonInfo(cmd.Context(), newClient(), args[0])
+		if err != nil {
+			return nil, err
+		}
+		return append([]string{info.UUID}, args[1:]...), nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil, errors.New(i18n.T("browse.no_lesson"))
+	}
+	uuid, err := pickLesson(cmd.Context())
+	if err != nil {
+		return nil, err
+	}
+	return []string{uuid}, nil
+}
+
+// pickLesson lets the user pick a course, then one of its lessons that
+// the CLI can run.
+func pickLesson(ctx context.Context) (string, error) {
+	client := newClient()
+	courses, err := client.FetchCourses(ctx)
+	if err != nil {
+		return "", explainAPIError(err)
+	}
+	if len(courses) == 0 {
+		return "", errors.New(i18n.T("browse.courses.empty"))
+	}
+	items := make([]render.PickerItem, len(courses))
+	for i, course := range courses {
+		items[i] = render.PickerItem{Title: course.Title, Detail: courseProgress(course)}
+	}
+	picked, err := render.Pick(i18n.T("browse.pick.course"), items, 0)
+	if err != nil {
+		return "", err
+	}
+	if picked < 0 {
+		return "", errors.New(i18n.T("browse.pick.none"))
+	}
+	course := courses[picked]
+
+	lessons, completed, err := fetchCourseLessons(ctx, client, course.UUID)
+	if err != nil {
+		return "", err
+	}
+	runnable := []api.CourseLesson{}
+	items = []render.PickerItem{}
+	// start at the first lesson that's left to do
+	selected := -1
+	for _, l := range lessons {
+		if l.Type != lesson.TypeHTTPTests && l.Type != lesson.TypeCLICommand {
+			continue
+		}
+		if selected < 0 && !completed[l.UUID] {
+			selected = len(runnable)
+		}
+		runnable = append(runnable, l)
+		items = append(items, render.PickerItem{
+			Title:  l.Title,
+			Detail: lessonTypeName(l.Type) + ", " + completionStatus(completed[l.UUID]),
+		})
+	}
+	if len(runnable) == 0 {
+		return "", errors.New(i18n.T("browse.pick.no_lessons", course.Title))
+	}
+	picked, err = render.Pick(i18n.T("browse.pick.lesson", course.Title), items, max(0, selected))
+	if err != nil {
+		return "", err
+	}
+	if picked < 0 {
+		return "", errors.New(i18n.T("browse.pick.none"))
+	}
+	fmt.Fprintln(os.Stderr, i18n.T("browse.pick.picked", runnable[picked].Title, runnable[picked].UUID))
+	return runnable[picked].UUID, nil
+}
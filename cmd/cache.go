@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: i18n.T("cache.short"),
+}
+
+var cacheListCmd = &cobra.Command{
+	Use:          "list",
+	Aliases:      []string{"ls"},
+	Short:        i18n.T("cache.list.short"),
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lessons, err := lessonCache()
+		if err != nil {
+			return err
+		}
+		entries, err := lessons.List()
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			fmt.Println(i18n.T("cache.list.empty"))
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, entry := range entries {
+			lessonType := "?"
+			if lesson, err := parseCachedLesson(entry.CachedLesson); err == nil {
+				lessonType = lesson.Lesson.Type
+			}
+			fetched := time.Since(entry.FetchedAt).Round(time.Second)
+			fmt.Fprintf(w, "%s\t%s\t%s\n", entry.UUID, lessonType, i18n.T("cache.list.fetched", fetched))
+		}
+		return w.Flush()
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:          "clear",
+	Short:        i18n.T("cache.clear.short"),
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lessons, err := lessonCache()
+		if err != nil {
+			return err
+		}
+		removed, err := lessons.Clear()
+		if err != nil {
+			return err
+		}
+		fmt.Println(i18n.T("cache.clear.success", removed))
+		return nil
+	},
+}
+
+var cachePrefetchCmd = &cobra.Command{
+	Use:          "prefetch COURSE",
+	Short:        i18n.T("cache.prefetch.short"),
+	Long:         i18n.T("cache.prefetch.long"),
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	PreRun:       compose(requireUpdated, requireAuth),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := newClient()
+		lessons, err := client.FetchCourseLessons(cmd.Context(), args[0])
+		if errors.Is(err, api.ErrNotFound) {
+			return errors.New(i18n.T("cache.prefetch.course_not_found", args[0]))
+		}
+		if err != nil {
+			return explainAPIError(err)
+		}
+		failed := 0
+		for i, lesson := range lessons {
+			fmt.Printf("[%d/%d] %s %s\n", i+1, len(lessons), lesson.UUID, lesson.Title)
+			if _, err := client.FetchLesson(cmd.Context(), lesson.UUID); err != nil {
+				fmt.Fprintln(os.Stderr, "  ", explainAPIError(err))
+				failed++
+			}
+		}
+		if failed > 0 {
+			return errors.New(i18n.T("cache.prefetch.failed", failed, len(lessons)))
+		}
+		fmt.Println(i18n.T("cache.prefetch.success", len(lessons), args[0]))
+		return nil
+	},
+}
+
+func parseCachedLesson(cached api.CachedLesson) (*api.Lesson, error) {
+	var lesson api.Lesson
+	if err := json.Unmarshal(cached.Data, &lesson); err != nil {
+		return nil, err
+	}
+	return &lesson, nil
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheListCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cachePrefetchCmd)
+}
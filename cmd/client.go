@@ -0,0 +1,374 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/bootdotdev/bootdev/cache"
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/credentials"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/profile"
+	"github.com/bootdotdev/bootdev/render"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+// configTokens keeps the tokens in the configured credential store and
+// the bookkeeping around them (last refresh, expiry) in the config file.
+// The tokens are loaded once per run, so a passphrase or keyring unlock
+// prompt is shown at most once. Tokens from the environment and tokens
+// refreshed while the config file is read-only only live in memory.
+type configTokens struct{}
+
+var tokenCache struct {
+	sync.Mutex
+	store credentials.Store
+	token *api.Token
+}
+
+func (configTokens) Token() (api.Token, error) {
+	tokenCache.Lock()
+	defer tokenCache.Unlock()
+	if tokenCache.token != nil {
+		return *tokenCache.token, nil
+	}
+	if tokensFromEnv() {
+		token := envToken
+		tokenCache.token = &token
+		return token, nil
+	}
+	store, err := credentialStore()
+	if err != nil {
+		return api.Token{}, err
+	}
+	token, err := store.Load()
+	if err != nil {
+		return api.Token{}, explainCredentialsError(err)
+	}
+	tokenCache.token = &token
+	return token, nil
+}
+
+func (configTokens) SetToken(token api.Token) error {
+	tokenCache.Lock()
+	defer tokenCache.Unlock()
+	tokenCache.token = &token
+	if tokensFromEnv() {
+		return nil
+	}
+
+	viper.Set(profile.Key("last_refresh"), time.Now().Unix())
+	var expires int64
+	if expiry, ok := api.TokenExpiry(token.AccessToken); ok {
+		expires = expiry.Unix()
+	}
+	viper.Set(profile.Key("access_token_expires"), expires)
+
+	store, err := credentialStore()
+	if err != nil {
+		return err
+	}
+	if !configWritable() {
+		// the plaintext store is the config file, every other one can
+		// still keep the rotated refresh token for the next run
+		if viper.GetString("credential_store") == credentials.Plaintext {
+			return nil
+		}
+		return explainCredentialsError(store.Save(token))
+	}
+	if err := store.Save(token); err != nil {
+		return explainCredentialsError(err)
+	}
+	return viper.WriteConfig()
+}
+
+// Delete removes the tokens from the credential store, along with their
+// bookkeeping in the config file.
+func (configTokens) Delete() error {
+	tokenCache.Lock()
+	defer tokenCache.Unlock()
+	tokenCache.token = &api.Token{}
+	if tokensFromEnv() {
+		return nil
+	}
+
+	viper.Set(profile.Key("last_refresh"), 0)
+	viper.Set(profile.Key("access_token_expires"), 0)
+
+	store, err := credentialStore()
+	if err != nil {
+		return err
+	}
+	if err := store.Delete(); err != nil {
+		return explainCredentialsError(err)
+	}
+	// like in SetToken, only the plaintext store needs the config written
+	if !configWritable() && viper.GetString("credential_store") != credentials.Plaintext {
+		return nil
+	}
+	return viper.WriteConfig()
+}
+
+// envToken holds the tokens passed in through BD_ACCESS_TOKEN and
+// BD_REFRESH_TOKEN, for CI where nobody is around to log in.
+var envToken api.Token
+
+// readEnvTokens takes the tokens out of the environment before viper
+// gets to see them. Otherwise writing the config would persist them,
+// and commands run by lessons would inherit them.
+func readEnvTokens() {
+	envToken = api.Token{
+		AccessToken:  os.Getenv("BD_ACCESS_TOKEN"),
+		RefreshToken: os.Getenv("BD_REFRESH_TOKEN"),
+	}
+	os.Unsetenv("BD_ACCESS_TOKEN")
+	os.Unsetenv("BD_REFRESH_TOKEN")
+}
+
+func tokensFromEnv() bool {
+	return envToken != api.Token{}
+}
+
+// configWritable reports whether the config file can be written, which
+// it can't be e.g. when it's mounted read-only into a container.
+func configWritable() bool {
+	file, err := os.OpenFile(viper.ConfigFileUsed(), os.O_WRONLY, 0)
+	if err != nil {
+		return false
+	}
+	file.Close()
+	return true
+}
+
+// credentialStore opens the configured store. It must be called with
+// tokenCache locked.
+func credentialStore() (credentials.Store, error) {
+	if tokenCache.store != nil {
+		return tokenCache.store, nil
+	}
+	store, err := openCredentialStore(viper.GetString("credential_store"), profile.Active())
+	if err != nil {
+		return nil, err
+	}
+	tokenCache.store = store
+	return store, nil
+}
+
+// moveCredentials copies the tokens of every profile into the given
+// store, removes them from the current one and configures the new store
+// to be used from now on.
+func moveCredentials(backend string) error {
+	tokenCache.Lock()
+	defer tokenCache.Unlock()
+	// the encrypted file is opened once per profile, its passphrase is
+	// only asked for once
+	passphrase := rememberPassphrase(promptPassphrase)
+	from := viper.GetString("credential_store")
+	sources := []credentials.Store{}
+	for _, name := range profile.Names() {
+		source, err := openCredentialStoreWith(from, name, passphrase)
+		if err != nil {
+			return err
+		}
+		token, err := source.Load()
+		if err != nil {
+			return explainCredentialsError(err)
+		}
+		if token == (api.Token{}) {
+			continue
+		}
+		target, err := openCredentialStoreWith(backend, name, passphrase)
+		if err != nil {
+			return err
+		}
+		if err := target.Save(token); err != nil {
+			return explainCredentialsError(err)
+		}
+		sources = append(sources, source)
+	}
+	// only once every profile made it, so a failure loses nothing
+	for _, source := range sources {
+		if err := source.Delete(); err != nil {
+			return explainCredentialsError(err)
+		}
+	}
+	tokenCache.store = nil
+
+	viper.Set("credential_store", backend)
+	if err := viper.WriteConfig(); err != nil {
+		return fmt.Errorf(i18n.T("config.write_error"), err)
+	}
+	return nil
+}
+
+// explainCredentialsError translates the errors of the credential stores
+// that are created before the locale is known. Anything else is returned
+// as is.
+func explainCredentialsError(err error) error {
+	if errors.Is(err, credentials.ErrPassphrase) {
+		return errors.New(i18n.T("credentials.wrong_passphrase"))
+	}
+	return err
+}
+
+func openCredentialStore(backend string, account string) (credentials.Store, error) {
+	return openCredentialStoreWith(backend, account, promptPassphrase)
+}
+
+func openCredentialStoreWith(backend string, account string, passphrase func(confirm bool) (string, error)) (credentials.Store, error) {
+	return credentials.Open(backend, credentials.Options{
+		Account:    account,
+		Path:       credentialsFile(),
+		Passphrase: passphrase,
+	})
+}
+
+// rememberPassphrase asks for the passphrase once and answers with it
+// from then on.
+func rememberPassphrase(prompt func(confirm bool) (string, error)) func(confirm bool) (string, error) {
+	var passphrase string
+	return func(confirm bool) (string, error) {
+		if passphrase != "" {
+			return passphrase, nil
+		}
+		var err error
+		passphrase, err = prompt(confirm)
+		return passphrase, err
+	}
+}
+
+func credentialsFile() string {
+	if file := viper.GetString("credentials_file"); file != "" {
+		return file
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return path.Join(home, ".config", "bootdev", "credentials.json")
+}
+
+// promptPassphrase reads the passphrase of the encrypted credentials
+// file from BD_CREDENTIALS_PASSPHRASE or, failing that, the terminal.
+func promptPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv("BD_CREDENTIALS_PASSPHRASE"); passphrase != "" {
+		return passphrase, nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errors.New(i18n.T("credentials.passphrase_required"))
+	}
+	read := func(prompt string) (string, error) {
+		fmt.Fprint(os.Stderr, prompt)
+		passphrase, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		return string(passphrase), err
+	}
+	passphrase, err := read(i18n.T("credentials.passphrase_prompt"))
+	if err != nil || !confirm {
+		return passphrase, err
+	}
+	if passphrase == "" {
+		return "", errors.New(i18n.T("credentials.passphrase_empty"))
+	}
+	again, err := read(i18n.T("credentials.passphrase_confirm"))
+	if err != nil {
+		return "", err
+	}
+	if again != passphrase {
+		return "", errors.New(i18n.T("credentials.passphrase_mismatch"))
+	}
+	return passphrase, nil
+}
+
+// Opaque tokens are assumed to live about an hour, so they're
+// refreshed once they're this old.
+const opaqueTokenRefreshAge = 55 * time.Minute
+
+// Tokens with a known expiry are refreshed this long before they expire.
+const tokenRefreshMargin = 5 * time.Minute
+
+// accessTokenExpiry returns when the stored access token expires, if
+// that's known. Configs written before the expiry was stored fall back
+// to reading it from the token itself.
+func accessTokenExpiry() (time.Time, bool) {
+	if tokensFromEnv() {
+		token, _ := configTokens{}.Token()
+		return api.TokenExpiry(token.AccessToken)
+	}
+	if expires := viper.GetInt64(profile.Key("access_token_expires")); expires > 0 {
+		return time.Unix(expires, 0), true
+	}
+	token, err := configTokens{}.Token()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return api.TokenExpiry(token.AccessToken)
+}
+
+// accessTokenIsStale reports whether the access token should be
+// refreshed before it's used.
+func accessTokenIsStale() bool {
+	if expiry, ok := accessTokenExpiry(); ok {
+		return time.Now().Add(tokenRefreshMargin).After(expiry)
+	}
+	if tokensFromEnv() {
+		// the times in the config belong to the stored tokens, so an
+		// opaque token from the environment is used until it's rejected
+		token, _ := configTokens{}.Token()
+		return token.AccessToken == ""
+	}
+	lastRefresh := time.Unix(viper.GetInt64(profile.Key("last_refresh")), 0)
+	return time.Since(lastRefresh) >= opaqueTokenRefreshAge
+}
+
+// newClient builds an API client from the current configuration.
+func newClient() *api.Client {
+	opts := []api.Option{
+		api.WithBaseURL(viper.GetString(profile.Key("api_url"))),
+		api.WithTokenStore(configTokens{}),
+		api.WithUserAgent("bootdev-cli/" + rootCmd.Version),
+		api.WithRetries(viper.GetInt("max_attempts")),
+		api.WithRetryNotifier(render.Retrying),
+	}
+	// without a cache lessons are simply fetched every time
+	if lessons, err := lessonCache(); err == nil {
+		opts = append(opts, api.WithLessonCache(lessons))
+	}
+	return api.New(opts...)
+}
+
+// lessonCache opens the cache of lessons of the active profile. Each
+// profile gets its own, since they may talk to different APIs.
+func lessonCache() (*cache.Lessons, error) {
+	lessons, err := cache.Open()
+	if err != nil {
+		return nil, err
+	}
+	return cache.New(profile.Dir(lessons.Dir())), nil
+}
+
+// explainAPIError turns the errors of API requests into advice on what
+// to do about them. Anything else is returned as is.
+func explainAPIError(err error) error {
+	var apiErr *api.APIError
+	switch {
+	case errors.Is(err, api.ErrUnauthorized):
+		return errors.New(i18n.T("api.unauthorized"))
+	case errors.Is(err, api.ErrRateLimited):
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			return errors.New(i18n.T("api.rate_limited_retry", apiErr.RetryAfter.Round(time.Second)))
+		}
+		return errors.New(i18n.T("api.rate_limited"))
+	case errors.Is(err, api.ErrServer):
+		return fmt.Errorf(i18n.T("api.server"), err)
+	case errors.Is(err, api.ErrNetwork):
+		return fmt.Errorf(i18n.T("api.network"), viper.GetString(profile.Key("api_url")), err)
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/credentials"
+	"github.com/spf13/viper"
+)
+
+func TestDeleteTokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bootdev.yaml")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	viper.SetConfigFile(path)
+	viper.Set("credential_store", credentials.Plaintext)
+	t.Cleanup(func() {
+		viper.Reset()
+		tokenCache.store = nil
+		tokenCache.token = nil
+	})
+	tokenCache.store, tokenCache.token = nil, nil
+
+	if err := (configTokens{}).SetToken(api.Token{AccessToken: "access", RefreshToken: "refresh"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := (configTokens{}).Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), ": refresh") || strings.Contains(string(data), ": access") {
+		t.Errorf("Expected the tokens gone from the config file, got:\n%s", data)
+	}
+	if token, err := (configTokens{}).Token(); err != nil || token != (api.Token{}) {
+		t.Errorf("Token() = %+v, %v after Delete, want no tokens", token, err)
+	}
+}
@@ -2,66 +2,146 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/profile"
+	"github.com/bootdotdev/bootdev/render"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-var configColors []*string
+var configColors = map[string]*string{}
 
-var defaultColorKeys []string
+var previewTheme bool
 
-var defaultColors = map[string]string{
-	"gray":  "8",
-	"red":   "1",
-	"green": "2",
-}
+var accessible bool
+
+var locale string
+
+var maxAttempts int
 
 // configureCmd represents the configure command
 var configureCmd = &cobra.Command{
 	Use:   "configure",
-	Short: "Change configuration of the CLI",
+	Short: i18n.T("configure.short"),
 	Run: func(cmd *cobra.Command, args []string) {
 		showHelp := true
-		for i, color := range configColors {
-			key := "color." + defaultColorKeys[i]
-			if color != nil {
-				if *color == "" {
-					viper.Set(key, defaultColors[defaultColorKeys[i]])
-					fmt.Println("unset " + key)
-				} else {
-					if viper.GetString(key) == *color {
-						continue
-					}
-					viper.Set(key, *color)
-					style := lipgloss.NewStyle().Foreground(lipgloss.Color(*color))
-					fmt.Println("set " + style.Render(key) + "!")
-				}
-				showHelp = false
+		for name, color := range configColors {
+			if !cmd.Flags().Changed("color-" + name) {
+				continue
+			}
+			key := "color." + name
+			if *color == "" {
+				viper.Set(profile.Key(key), "")
+				fmt.Println(i18n.T("configure.unset", key))
+			} else {
+				viper.Set(profile.Key(key), *color)
+				style := lipgloss.NewStyle().Foreground(lipgloss.Color(*color))
+				fmt.Println(i18n.T("configure.set", style.Render(key)))
+			}
+			showHelp = false
+		}
+		if cmd.Flags().Changed("accessible") {
+			viper.Set("accessible", accessible)
+			if accessible {
+				fmt.Println(i18n.T("configure.accessible_enabled"))
+			} else {
+				fmt.Println(i18n.T("configure.accessible_disabled"))
+			}
+			showHelp = false
+		}
+		if cmd.Flags().Changed("locale") {
+			if locale != "" && !slices.Contains(i18n.Locales(), locale) {
+				fmt.Println(i18n.T("configure.unknown_locale", locale, strings.Join(i18n.Locales(), ", ")))
+				return
 			}
+			viper.Set("locale", locale)
+			i18n.SetLocale(i18n.Detect(locale))
+			fmt.Println(i18n.T("configure.locale_set", i18n.Locale()))
+			showHelp = false
+		}
+		if cmd.Flags().Changed("max-attempts") {
+			if maxAttempts < 1 {
+				fmt.Println(i18n.T("configure.invalid_max_attempts"))
+				return
+			}
+			viper.Set("max_attempts", maxAttempts)
+			fmt.Println(i18n.T("configure.max_attempts_set", maxAttempts))
+			showHelp = false
 		}
 		if showHelp {
 			// Handle errors if any from the Help function
 			if err := cmd.Help(); err != nil {
-				fmt.Printf("Error showing help: %v\n", err)
+				fmt.Println(i18n.T("configure.help_error", err))
 			}
 		} else {
 			if err := viper.WriteConfig(); err != nil {
-				fmt.Printf("Error writing config: %v\n", err)
+				fmt.Println(i18n.T("config.write_error", err))
+			}
+		}
+	},
+}
+
+var configureThemeCmd = &cobra.Command{
+	Use:          "theme [name]",
+	Short:        i18n.T("configure.theme.short"),
+	Long:         i18n.T("configure.theme.long"),
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := render.ActiveTheme()
+		if len(args) > 0 {
+			name = args[0]
+		}
+		if _, err := render.LoadTheme(name); err != nil {
+			return err
+		}
+
+		if previewTheme {
+			preview, err := render.PreviewTheme(name)
+			if err != nil {
+				return err
+			}
+			fmt.Print(preview)
+			return nil
+		}
+
+		if len(args) == 0 {
+			for _, theme := range render.ThemeNames() {
+				if theme == name {
+					fmt.Printf("* %s\n", theme)
+				} else {
+					fmt.Printf("  %s\n", theme)
+				}
 			}
+			return nil
 		}
+
+		viper.Set("theme", name)
+		if err := viper.WriteConfig(); err != nil {
+			return fmt.Errorf(i18n.T("config.write_error"), err)
+		}
+		fmt.Println(i18n.T("configure.theme.set", name))
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(configureCmd)
+	configureCmd.AddCommand(configureThemeCmd)
+	configureThemeCmd.Flags().BoolVarP(&previewTheme, "preview", "p", false, i18n.T("configure.theme.flag.preview"))
+
+	configureCmd.Flags().BoolVar(&accessible, "accessible", false, i18n.T("configure.flag.accessible"))
+	viper.SetDefault("accessible", false)
+	configureCmd.Flags().StringVar(&locale, "locale", "", i18n.T("configure.flag.locale", strings.Join(i18n.Locales(), ", ")))
+	configureCmd.Flags().IntVar(&maxAttempts, "max-attempts", api.DefaultMaxAttempts, i18n.T("configure.flag.max_attempts"))
+	viper.SetDefault("max_attempts", api.DefaultMaxAttempts)
 
-	configColors = make([]*string, 0, len(defaultColors))    // Adjusted to start with a size of 0 and append
-	defaultColorKeys = make([]string, 0, len(defaultColors)) // Adjusted to start with a size of 0 and append
-	for color, def := range defaultColors {
-		configColors = append(configColors, configureCmd.Flags().String("color-"+color, def, "ANSI number or hex string"))
-		defaultColorKeys = append(defaultColorKeys, color)
-		viper.SetDefault("color."+color, def)
+	for _, color := range []string{"gray", "red", "green"} {
+		configColors[color] = configureCmd.Flags().String("color-"+color, "", i18n.T("configure.flag.color", color))
 	}
 }
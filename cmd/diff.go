@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bootdotdev/bootdev/checks"
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/history"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/render"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:          "diff RUN_A RUN_B",
+	Short:        i18n.T("diff.short"),
+	Long:         i18n.T("diff.long"),
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runA, err := findRun(args[0])
+		if err != nil {
+			return err
+		}
+		runB, err := findRun(args[1])
+		if err != nil {
+			return err
+		}
+		if runA.Type != runB.Type {
+			return errors.New(i18n.T("diff.different_types", runA.ID, runB.ID))
+		}
+		lessonA, resultsA, err := decodeRun(runA)
+		if err != nil {
+			return err
+		}
+		lessonB, resultsB, err := decodeRun(runB)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(i18n.T("diff.header", runA.ID, runA.Time.Local().Format(time.DateTime), runB.ID, runB.Time.Local().Format(time.DateTime)))
+		if lessonOf(*runA) != lessonOf(*runB) {
+			// still worth comparing, e.g. a lesson file and the lesson it became
+			fmt.Fprintln(os.Stderr, i18n.T("diff.different_lessons", lessonOf(*runA), lessonOf(*runB)))
+		}
+		fmt.Println()
+
+		switch resultsA := resultsA.(type) {
+		case []checks.HttpTestResult:
+			render.HTTPRunDiff(
+				render.HTTPRunResults{ID: runA.ID, Data: *lessonA.Lesson.LessonDataHTTPTests, Results: resultsA, Verdict: runVerdictOf(*runA)},
+				render.HTTPRunResults{ID: runB.ID, Data: *lessonB.Lesson.LessonDataHTTPTests, Results: resultsB.([]checks.HttpTestResult), Verdict: runVerdictOf(*runB)},
+				diffContext,
+			)
+		case []api.CLICommandResult:
+			render.CommandRunDiff(
+				render.CommandRunResults{ID: runA.ID, Data: *lessonA.Lesson.LessonDataCLICommand, Results: resultsA, Verdict: runVerdictOf(*runA)},
+				render.CommandRunResults{ID: runB.ID, Data: *lessonB.Lesson.LessonDataCLICommand, Results: resultsB.([]api.CLICommandResult), Verdict: runVerdictOf(*runB)},
+				diffContext,
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().IntVar(&diffContext, "diff-context", render.DefaultDiffContext, i18n.T("diff.flag.diff_context"))
+}
+
+// runVerdictOf is the verdict run was recorded with, if any.
+func runVerdictOf(run history.Run) render.RunVerdict {
+	return render.RunVerdict{Passed: run.Passed, FailedIndex: run.FailedIndex, FailedTestIndex: run.FailedTestIndex}
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bootdotdev/bootdev/checks"
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/history"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/lesson"
+	"github.com/bootdotdev/bootdev/profile"
+	"github.com/bootdotdev/bootdev/render"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:          "history [UUID]",
+	Short:        i18n.T("history.short"),
+	Long:         i18n.T("history.long"),
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log, err := runHistory()
+		if err != nil {
+			return err
+		}
+		runs, err := log.List()
+		if err != nil {
+			return err
+		}
+		if len(args) == 1 {
+			// lesson files are recorded by their absolute path
+			file, _ := filepath.Abs(args[0])
+			filtered := []history.Run{}
+			for _, run := range runs {
+				if run.LessonUUID == args[0] || run.LessonFile == file {
+					filtered = append(filtered, run)
+				}
+			}
+			runs = filtered
+		}
+		if len(runs) == 0 {
+			fmt.Println(i18n.T("history.empty"))
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, run := range runs {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", run.ID, run.Time.Local().Format(time.DateTime),
+				lessonOf(run), run.Mode, runVerdict(run))
+		}
+		return w.Flush()
+	},
+}
+
+var historyShowCmd = &cobra.Command{
+	Use:          "show ID",
+	Short:        i18n.T("history.show.short"),
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		run, err := findRun(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(i18n.T("history.show.header", run.ID, lessonOf(*run), run.Time.Local().Format(time.DateTime)))
+		fmt.Println()
+		return showRun(run)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.AddCommand(historyShowCmd)
+}
+
+// runHistory opens the history of the active profile.
+func runHistory() (*history.Log, error) {
+	log, err := history.Open()
+	if err != nil {
+		return nil, err
+	}
+	return history.New(profile.Dir(log.Dir())), nil
+}
+
+func findRun(id string) (*history.Run, error) {
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errors.New(i18n.T("history.invalid_id", id))
+	}
+	log, err := runHistory()
+	if err != nil {
+		return nil, err
+	}
+	run, err := log.Get(n)
+	if errors.Is(err, history.ErrNotFound) {
+		return nil, errors.New(i18n.T("history.not_found", n))
+	}
+	return run, err
+}
+
+// lessonOf is the lesson a run was of, its UUID or its file.
+func lessonOf(run history.Run) string {
+	if run.LessonFile != "" {
+		return run.LessonFile
+	}
+	return run.LessonUUID
+}
+
+func runVerdict(run history.Run) string {
+	switch {
+	case run.Passed == nil:
+		return "-"
+	case *run.Passed:
+		return i18n.T("history.passed")
+	case run.FailedIndex == nil || run.FailedTestIndex == nil:
+		return i18n.T("history.failed")
+	case run.Type == lesson.TypeCLICommand:
+		return i18n.T("history.failed_command", *run.FailedIndex+1, *run.FailedTestIndex+1)
+	default:
+		return i18n.T("history.failed_request", *run.FailedIndex+1, *run.FailedTestIndex+1)
+	}
+}
+
+// recordRun adds a run to the history. The run itself already happened,
+// so a history that can't be written is only worth a warning.
+func recordRun(run history.Run, lessonData *api.Lesson, results any) {
+	err := func() error {
+		data, err := json.Marshal(lessonData)
+		if err != nil {
+			return err
+		}
+		stored, err := encodeResults(results)
+		if err != nil {
+			return err
+		}
+		log, err := runHistory()
+		if err != nil {
+			return err
+		}
+		run.Time = time.Now()
+		run.Type = lessonData.Lesson.Type
+		run.Lesson = data
+		run.Results = stored
+		_, err = log.Add(run)
+		return err
+	}()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("history.save_error", err))
+	}
+}
+
+func lessonFileRun(path string) history.Run {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return history.Run{LessonFile: path, Mode: history.ModeLocal}
+}
+
+func withHTTPVerdict(run history.Run, failure *api.HTTPTestValidationError) history.Run {
+	passed := failure == nil
+	run.Passed = &passed
+	if failure != nil {
+		run.FailedIndex = failure.FailedRequestIndex
+		run.FailedTestIndex = failure.FailedTestIndex
+		if failure.ErrorMessage != nil {
+			run.Message = *failure.ErrorMessage
+		}
+	}
+	return run
+}
+
+func withCLIVerdict(run history.Run, failure *api.StructuredErrCLICommand) history.Run {
+	passed := failure == nil
+	run.Passed = &passed
+	if failure != nil {
+		run.FailedIndex = &failure.FailedCommandIndex
+		run.FailedTestIndex = &failure.FailedTestIndex
+		run.Message = failure.ErrorMessage
+	}
+	return run
+}
+
+// decodeRun returns the lesson of a run and its results, which are
+// either []checks.HttpTestResult or []api.CLICommandResult.
+func decodeRun(run *history.Run) (*api.Lesson, any, error) {
+	var lessonData api.Lesson
+	if err := json.Unmarshal(run.Lesson, &lessonData); err != nil {
+		return nil, nil, err
+	}
+	results, err := decodeResults(&lessonData, run.Results)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &lessonData, results, nil
+}
+
+// showRun renders a past run the way it was rendered when it ran.
+func showRun(run *history.Run) error {
+	lessonData, results, err := decodeRun(run)
+	if err != nil {
+		return err
+	}
+	switch results := results.(type) {
+	case []checks.HttpTestResult:
+		data := *lessonData.Lesson.LessonDataHTTPTests
+		var failure *api.HTTPTestValidationError
+		if run.Passed != nil && !*run.Passed && run.FailedIndex != nil && run.FailedTestIndex != nil {
+			failure = &api.HTTPTestValidationError{
+				ErrorMessage:       &run.Message,
+				FailedRequestIndex: run.FailedIndex,
+				FailedTestIndex:    run.FailedTestIndex,
+			}
+		}
+		switch run.Mode {
+		case history.ModeSubmit:
+			render.HTTPSubmission(data, results, failure, render.DefaultDiffContext)
+		case history.ModeLocal:
+			render.HTTPLocal(data, results, failure, render.DefaultDiffContext)
+		default:
+			render.HTTPRun(data, results)
+		}
+	case []api.CLICommandResult:
+		data := *lessonData.Lesson.LessonDataCLICommand
+		var failure *api.StructuredErrCLICommand
+		if run.Passed != nil && !*run.Passed && run.FailedIndex != nil && run.FailedTestIndex != nil {
+			failure = &api.StructuredErrCLICommand{
+				ErrorMessage:       run.Message,
+				FailedCommandIndex: *run.FailedIndex,
+				FailedTestIndex:    *run.FailedTestIndex,
+			}
+		}
+		switch run.Mode {
+		case history.ModeSubmit:
+			render.CommandSubmission(data, results, failure, render.DefaultDiffContext)
+		case history.ModeLocal:
+			render.CommandLocal(data, results, failure, render.DefaultDiffContext)
+		default:
+			render.CommandRun(data, results)
+		}
+	}
+	return nil
+}
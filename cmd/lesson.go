@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/lesson"
+	"github.com/spf13/cobra"
+)
+
+var lessonCmd = &cobra.Command{
+	Use:   "lesson",
+	Short: i18n.T("lesson.short"),
+}
+
+var lessonLintCmd = &cobra.Command{
+	Use:          "lint FILE...",
+	Short:        i18n.T("lesson.lint.short"),
+	Long:         i18n.T("lesson.lint.long"),
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		failed := 0
+		for _, path := range args {
+			if err := lintLesson(path); err != nil {
+				fmt.Fprintln(os.Stderr, i18n.T("lesson.lint.problems", path))
+				fmt.Fprintln(os.Stderr, err)
+				failed++
+				continue
+			}
+			fmt.Println(i18n.T("lesson.lint.ok", path))
+		}
+		if failed > 0 {
+			return errors.New(i18n.T("lesson.lint.failed", failed, len(args)))
+		}
+		return nil
+	},
+}
+
+var lessonSchemaCmd = &cobra.Command{
+	Use:          "schema",
+	Short:        i18n.T("lesson.schema.short"),
+	Long:         i18n.T("lesson.schema.long"),
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(lesson.Schema())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lessonCmd)
+	lessonCmd.AddCommand(lessonLintCmd)
+	lessonCmd.AddCommand(lessonSchemaCmd)
+}
+
+func lintLesson(path string) error {
+	lessonData, err := lesson.Load(path)
+	if err != nil {
+		return err
+	}
+	return lesson.Validate(lessonData)
+}
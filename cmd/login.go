@@ -2,16 +2,28 @@ package cmd
 
 import (
 	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/profile"
+	"github.com/bootdotdev/bootdev/render"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/pkg/browser"
 	"github.com/spf13/cobra"
@@ -68,123 +80,311 @@ func logoRenderer() string {
 	return output
 }
 
+var loginDevice bool
+
+var loginCodePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+var loginPort int
+
+var loginWithToken bool
+
+func init() {
+	rootCmd.AddCommand(LoginCmd)
+	LoginCmd.Flags().BoolVar(&loginDevice, "device", false, i18n.T("login.flag.device"))
+	LoginCmd.Flags().IntVar(&loginPort, "port", 0, i18n.T("login.flag.port"))
+	LoginCmd.Flags().BoolVar(&loginWithToken, "with-token", false, i18n.T("login.flag.with_token"))
+	LoginCmd.MarkFlagsMutuallyExclusive("device", "with-token")
+
+	// auth used to be an alias of login, before it became a group of its
+	// own; on its own it still logs in, with the same flags
+	authCmd.Flags().AddFlagSet(LoginCmd.Flags())
+	authCmd.MarkFlagsMutuallyExclusive("device", "with-token")
+	authCmd.SilenceUsage = true
+	authCmd.PreRun = LoginCmd.PreRun
+	authCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		fmt.Fprintln(os.Stderr, i18n.T("auth.login_moved"))
+		return LoginCmd.RunE(cmd, args)
+	}
+}
+
 var LoginCmd = &cobra.Command{
 	Use:          "login",
-	Aliases:      []string{"auth", "authenticate", "signin"},
-	Short:        "Authenticate the CLI with your account",
+	Aliases:      []string{"authenticate", "signin"},
+	Short:        i18n.T("login.short"),
 	SilenceUsage: true,
 	PreRun:       requireUpdated,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if tokensFromEnv() {
+			return errors.New(i18n.T("login.env_tokens"))
+		}
+		if !configWritable() {
+			return errors.New(i18n.T("login.read_only_config", viper.ConfigFileUsed()))
+		}
+		if loginWithToken {
+			creds, err := tokenLogin(cmd.Context(), cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			return saveLogin(creds)
+		}
+
 		w, _, err := term.GetSize(0)
 		if err != nil {
 			w = 0
 		}
 		// Pad the logo with whitespace
-		welcome := lipgloss.PlaceHorizontal(lipgloss.Width(logo), lipgloss.Center, "Welcome to the boot.dev CLI!")
+		welcome := lipgloss.PlaceHorizontal(lipgloss.Width(logo), lipgloss.Center, i18n.T("login.welcome"))
 
-		if w >= lipgloss.Width(welcome) {
+		if w >= lipgloss.Width(welcome) && !render.Accessible() {
 			fmt.Println(logoRenderer())
 			fmt.Print(welcome, "\n\n")
 		} else {
-			fmt.Print("Welcome to the boot.dev CLI!\n\n")
+			fmt.Print(i18n.T("login.welcome"), "\n\n")
 		}
 
-		loginUrl := viper.GetString("base_url") + "/cli/login"
+		var creds *api.LoginResponse
+		if loginDevice {
+			creds, err = deviceLogin(cmd.Context())
+		} else {
+			creds, err = browserLogin(cmd.Context())
+		}
+		if err != nil {
+			return err
+		}
+		return saveLogin(creds)
+	},
+}
 
-		fmt.Println("Please navigate to:\n" + loginUrl)
+func saveLogin(creds *api.LoginResponse) error {
+	if creds.AccessToken == "" || creds.RefreshToken == "" {
+		return errors.New(i18n.T("login.invalid_credentials"))
+	}
 
-		inputChan := make(chan string)
+	err := configTokens{}.SetToken(api.Token{AccessToken: creds.AccessToken, RefreshToken: creds.RefreshToken})
+	if err != nil {
+		return err
+	}
 
-		go func() {
-			reader := bufio.NewReader(os.Stdin)
-			fmt.Print("\nPaste your login code: ")
-			text, _ := reader.ReadString('\n')
-			inputChan <- text
-		}()
+	fmt.Println(i18n.T("login.success"))
+	return nil
+}
 
-		go func() {
-			startHTTPServer(inputChan)
-		}()
+// tokenLogin reads a refresh token from stdin, e.g. from a CI secret,
+// and exchanges it for a fresh pair of tokens.
+func tokenLogin(ctx context.Context, stdin io.Reader) (*api.LoginResponse, error) {
+	input, err := io.ReadAll(io.LimitReader(stdin, 64*1024))
+	if err != nil {
+		return nil, err
+	}
+	refreshToken := strings.TrimSpace(string(input))
+	if refreshToken == "" {
+		return nil, errors.New(i18n.T("login.empty_token"))
+	}
+	client := api.New(
+		api.WithBaseURL(viper.GetString(profile.Key("api_url"))),
+		api.WithTokenSource(api.StaticTokenSource(api.Token{RefreshToken: refreshToken})),
+		api.WithUserAgent("bootdev-cli/"+rootCmd.Version),
+	)
+	return client.FetchAccessToken(ctx)
+}
 
-		// attempt to open the browser
-		go func() {
-			browser.Stdout = nil
-			browser.Stderr = nil
-			if err := browser.OpenURL(loginUrl); err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to open browser: %v\n", err)
-			}
-		}()
+// browserLogin opens the login page and waits for the code, either
+// pasted by the user or sent back to the local server by the page.
+func browserLogin(ctx context.Context) (*api.LoginResponse, error) {
+	baseURL := viper.GetString(profile.Key("base_url"))
+	loginUrl := baseURL + "/cli/login"
 
-		// race the web server against the user's input
-		text := <-inputChan
+	// a buffer of two, so neither the server nor the prompt blocks
+	// when the other one got a code first
+	inputChan := make(chan string, 2)
 
-		re := regexp.MustCompile(`[^A-Za-z0-9_-]`)
-		text = re.ReplaceAllString(text, "")
-		creds, err := api.LoginWithCode(text)
-		if err != nil {
-			return err
-		}
+	callback, err := startLoginCallback(baseURL, loginPort, inputChan)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	} else {
+		loginUrl += "?" + callback.query()
+	}
 
-		if creds.AccessToken == "" || creds.RefreshToken == "" {
-			return errors.New("invalid credentials received")
-		}
+	fmt.Println(i18n.T("login.navigate", loginUrl))
 
-		viper.Set("access_token", creds.AccessToken)
-		viper.Set("refresh_token", creds.RefreshToken)
-		viper.Set("last_refresh", time.Now().Unix())
+	go func() {
+		reader := bufio.NewReader(os.Stdin)
+		fmt.Print("\n", i18n.T("login.paste_code"))
+		text, _ := reader.ReadString('\n')
+		inputChan <- text
+	}()
 
-		if err := viper.WriteConfig(); err != nil {
-			return err
+	// attempt to open the browser
+	go func() {
+		browser.Stdout = nil
+		browser.Stderr = nil
+		if err := browser.OpenURL(loginUrl); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("login.browser_error", err))
 		}
+	}()
 
-		fmt.Println("Logged in successfully!")
-		return nil
-	},
+	// race the web server against the user's input
+	text := <-inputChan
+
+	re := regexp.MustCompile(`[^A-Za-z0-9_-]`)
+	text = re.ReplaceAllString(text, "")
+	if callback != nil {
+		// the code is in, whichever way it came
+		callback.shutdown()
+	}
+	return newClient().LoginWithCode(ctx, text)
 }
 
-func cors(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		next.ServeHTTP(w, r)
+// deviceLogin shows a code to enter on any other device and waits for
+// the login to be approved there.
+func deviceLogin(ctx context.Context) (*api.LoginResponse, error) {
+	client := newClient()
+	code, err := client.RequestDeviceCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := render.DeviceLogin(ctx, *code, func(ctx context.Context) (*api.LoginResponse, error) {
+		return client.WaitForDeviceToken(ctx, code)
 	})
+	switch {
+	case errors.Is(err, api.ErrDeviceCodeExpired):
+		return nil, errors.New(i18n.T("login.device_expired"))
+	case errors.Is(err, api.ErrAccessDenied):
+		return nil, errors.New(i18n.T("login.device_denied"))
+	case errors.Is(err, context.Canceled):
+		return nil, errors.New(i18n.T("login.device_canceled"))
+	}
+	return creds, err
 }
 
-func startHTTPServer(inputChan chan string) {
-	handleSubmit := func(res http.ResponseWriter, req *http.Request) {
-		code, err := io.ReadAll(req.Body)
-		if err != nil {
-			http.Error(res, "Failed to read request body", http.StatusInternalServerError)
-			return
+// loginCallback is the local server the login page sends the code back
+// to. It only listens on loopback, only answers the login page's origin
+// and only accepts a code along with the state handed out for this login.
+type loginCallback struct {
+	srv    *http.Server
+	port   int
+	state  string
+	origin string
+	codes  chan<- string
+	once   sync.Once
+}
+
+// startLoginCallback listens on port, or on any free port if it's zero,
+// and sends the first valid code it receives to codes.
+func startLoginCallback(baseURL string, port int, codes chan<- string) (*loginCallback, error) {
+	origin, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	state := make([]byte, 16)
+	if _, err := rand.Read(state); err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		if errors.Is(err, syscall.EADDRINUSE) {
+			return nil, errors.New(i18n.T("login.port_taken", port))
 		}
-		inputChan <- string(code)
-		// Clear current line
-		fmt.Print("\n\033[1A\033[K")
+		return nil, errors.New(i18n.T("login.server_error", err))
 	}
 
-	handleHealth := func(res http.ResponseWriter, req *http.Request) {
-		// Send 200 OK for health check
-		if _, err := res.Write([]byte("OK")); err != nil {
-			// Use fmt.Fprintf for formatting with %v
-			fmt.Fprintf(os.Stderr, "Failed to write health check response: %v\n", err)
-		}
+	c := &loginCallback{
+		port:   listener.Addr().(*net.TCPAddr).Port,
+		state:  base64.RawURLEncoding.EncodeToString(state),
+		origin: origin.Scheme + "://" + origin.Host,
+		codes:  codes,
 	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/submit", c.handleSubmit)
+	mux.HandleFunc("/health", c.handleHealth)
+	c.srv = &http.Server{
+		Handler:      mux,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := c.srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+			fmt.Fprintln(os.Stderr, i18n.T("login.server_error", err))
+		}
+	}()
+	return c, nil
+}
 
-	// Define the HTTP server with timeouts
-	srv := &http.Server{
-		Addr:         "localhost:9417", // Address and port
-		ReadTimeout:  5 * time.Second,  // Read timeout
-		WriteTimeout: 10 * time.Second, // Write timeout
-		Handler:      nil,              // Use default ServeMux
+// query tells the login page where to send the code and which state to
+// send along with it.
+func (c *loginCallback) query() string {
+	return url.Values{
+		"port":  {strconv.Itoa(c.port)},
+		"state": {c.state},
+	}.Encode()
+}
+
+// shutdown stops the server, letting a response in flight finish first.
+func (c *loginCallback) shutdown() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	c.srv.Shutdown(ctx)
+}
+
+// allowOrigin sets the CORS headers for the login page. Requests from
+// any other origin are refused.
+func (c *loginCallback) allowOrigin(res http.ResponseWriter, req *http.Request) bool {
+	if req.Header.Get("Origin") != c.origin {
+		http.Error(res, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return false
 	}
+	res.Header().Set("Access-Control-Allow-Origin", c.origin)
+	res.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	res.Header().Set("Vary", "Origin")
+	return true
+}
 
-	// Register the handlers
-	http.Handle("/submit", cors(http.HandlerFunc(handleSubmit)))
-	http.Handle("/health", cors(http.HandlerFunc(handleHealth)))
+func (c *loginCallback) handleHealth(res http.ResponseWriter, req *http.Request) {
+	if !c.allowOrigin(res, req) {
+		return
+	}
+	if _, err := res.Write([]byte("OK")); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("login.health_error", err))
+	}
+}
 
-	// Start the server with the configured timeouts
-	err := srv.ListenAndServe()
-	if err != nil && err != http.ErrServerClosed {
-		fmt.Printf("Server failed: %s\n", err)
+func (c *loginCallback) handleSubmit(res http.ResponseWriter, req *http.Request) {
+	if !c.allowOrigin(res, req) {
+		return
 	}
+	switch req.Method {
+	case http.MethodOptions:
+		res.WriteHeader(http.StatusNoContent)
+		return
+	case http.MethodPost:
+	default:
+		http.Error(res, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	state := req.URL.Query().Get("state")
+	if subtle.ConstantTimeCompare([]byte(state), []byte(c.state)) != 1 {
+		http.Error(res, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	code, err := io.ReadAll(http.MaxBytesReader(res, req.Body, 4096))
+	if err != nil {
+		http.Error(res, i18n.T("login.read_body_error"), http.StatusBadRequest)
+		return
+	}
+	if !loginCodePattern.Match(code) {
+		http.Error(res, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	// only the first code counts, the prompt may still send one too
+	c.once.Do(func() {
+		c.codes <- string(code)
+		if render.Accessible() {
+			fmt.Println()
+		} else {
+			// Clear current line
+			fmt.Print("\n\033[1A\033[K")
+		}
+	})
 }
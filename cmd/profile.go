@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/profile"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var profileAPIURL string
+
+var profileBaseURL string
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: i18n.T("profile.short"),
+	Long:  i18n.T("profile.long"),
+}
+
+var profileListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   i18n.T("profile.list.short"),
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, name := range profile.Names() {
+			marker := " "
+			if name == profile.Active() {
+				marker = "*"
+			}
+			fmt.Printf("%s %s\t%s\n", marker, name, viper.GetString(profile.KeyFor(name, "api_url")))
+		}
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:          "use NAME",
+	Short:        i18n.T("profile.use.short"),
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
+		if !profile.Exists(name) {
+			return fmt.Errorf(i18n.T("profile.unknown"), name, strings.Join(profile.Names(), ", "))
+		}
+		viper.Set("active_profile", name)
+		if err := viper.WriteConfig(); err != nil {
+			return fmt.Errorf(i18n.T("config.write_error"), err)
+		}
+		fmt.Println(i18n.T("profile.use.success", name))
+		return nil
+	},
+}
+
+var profileAddCmd = &cobra.Command{
+	Use:          "add NAME",
+	Short:        i18n.T("profile.add.short"),
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if err := profile.ValidateName(name); err != nil {
+			return err
+		}
+		viper.Set(profile.KeyFor(name, "api_url"), profileAPIURL)
+		viper.Set(profile.KeyFor(name, "base_url"), profileBaseURL)
+		if err := viper.WriteConfig(); err != nil {
+			return fmt.Errorf(i18n.T("config.write_error"), err)
+		}
+		fmt.Println(i18n.T("profile.add.success", name))
+		fmt.Println(i18n.T("profile.add.login", name))
+		return nil
+	},
+}
+
+var profileRemoveCmd = &cobra.Command{
+	Use:          "remove NAME",
+	Aliases:      []string{"rm"},
+	Short:        i18n.T("profile.remove.short"),
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
+		if !profile.Exists(name) {
+			return fmt.Errorf(i18n.T("profile.unknown"), name, strings.Join(profile.Names(), ", "))
+		}
+		if name != profile.Default && name != profile.Active() {
+			// the tokens of the plaintext store go away with the profile
+			store, err := openCredentialStore(viper.GetString("credential_store"), name)
+			if err != nil {
+				return err
+			}
+			if err := store.Delete(); err != nil {
+				return explainCredentialsError(err)
+			}
+		}
+		if viper.GetString("active_profile") == name {
+			viper.Set("active_profile", profile.Default)
+		}
+		if err := profile.Remove(name); err != nil {
+			return err
+		}
+		fmt.Println(i18n.T("profile.remove.success", name))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileAddCmd)
+	profileCmd.AddCommand(profileRemoveCmd)
+	profileAddCmd.Flags().StringVar(&profileAPIURL, "api-url", api.DefaultBaseURL, i18n.T("profile.add.flag.api_url"))
+	profileAddCmd.Flags().StringVar(&profileBaseURL, "base-url", defaultBaseURL, i18n.T("profile.add.flag.base_url"))
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/lesson"
+	"github.com/spf13/cobra"
+)
+
+// maxRecordedBody is how much of a body is kept. The rest still goes
+// through the proxy, but isn't part of the draft.
+const maxRecordedBody = 1 << 20
+
+var recordBaseURL string
+var recordPort int
+var recordOutput string
+
+var lessonRecordCmd = &cobra.Command{
+	Use:          "record",
+	Short:        i18n.T("lesson.record.short"),
+	Long:         i18n.T("lesson.record.long"),
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, err := url.Parse(recordBaseURL)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return errors.New(i18n.T("lesson.record.invalid_base_url", recordBaseURL))
+		}
+
+		listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(recordPort)))
+		if err != nil {
+			return err
+		}
+		recorder := &recorder{target: target}
+		srv := &http.Server{Handler: recorder.proxy()}
+		go srv.Serve(listener)
+
+		fmt.Fprintln(os.Stderr, i18n.T("lesson.record.listening", "http://"+listener.Addr().String(), recordBaseURL))
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+
+		exchanges := recorder.recorded()
+		if len(exchanges) == 0 {
+			fmt.Fprintln(os.Stderr, i18n.T("lesson.record.none"))
+			return nil
+		}
+		draft, err := lesson.Marshal(lesson.Draft(recordBaseURL, exchanges))
+		if err != nil {
+			return err
+		}
+		if recordOutput == "" {
+			_, err := os.Stdout.Write(draft)
+			return err
+		}
+		// the recorded requests may still hold secrets in bodies or headers
+		if err := os.WriteFile(recordOutput, draft, 0o600); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, i18n.T("lesson.record.written", len(exchanges), recordOutput))
+		return nil
+	},
+}
+
+func init() {
+	lessonCmd.AddCommand(lessonRecordCmd)
+	lessonRecordCmd.Flags().StringVar(&recordBaseURL, "base-url", "", i18n.T("lesson.record.flag.base_url"))
+	lessonRecordCmd.Flags().IntVar(&recordPort, "port", 8081, i18n.T("lesson.record.flag.port"))
+	lessonRecordCmd.Flags().StringVarP(&recordOutput, "output", "o", "", i18n.T("lesson.record.flag.output"))
+	lessonRecordCmd.MarkFlagRequired("base-url")
+}
+
+// recorder is a reverse proxy to the server a lesson is recorded from,
+// which keeps every exchange that goes through it.
+type recorder struct {
+	target    *url.URL
+	mu        sync.Mutex
+	exchanges []lesson.Exchange
+}
+
+func (rec *recorder) proxy() http.Handler {
+	proxy := httputil.NewSingleHostReverseProxy(rec.target)
+	director := proxy.Director
+	proxy.Director = func(r *http.Request) {
+		director(r)
+		// so that the response body can be read as it is
+		r.Header.Del("Accept-Encoding")
+	}
+	proxy.ModifyResponse = func(resp *http.Response) error {
+		exchange, ok := resp.Request.Context().Value(exchangeContextKey{}).(*lesson.Exchange)
+		if !ok {
+			return nil
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxRecordedBody))
+		if err != nil {
+			return err
+		}
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		exchange.StatusCode = resp.StatusCode
+		exchange.ResponseHeaders = resp.Header.Clone()
+		exchange.ResponseBody = body
+		rec.add(*exchange)
+		return nil
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// browsers ask before cross-origin requests, that isn't part of the lesson
+		if r.Method == http.MethodOptions {
+			proxy.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxRecordedBody))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+		exchange := &lesson.Exchange{
+			Method:  r.Method,
+			Path:    r.URL.RequestURI(),
+			Headers: r.Header.Clone(),
+			Body:    body,
+		}
+		ctx := context.WithValue(r.Context(), exchangeContextKey{}, exchange)
+		proxy.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+type exchangeContextKey struct{}
+
+func (rec *recorder) add(exchange lesson.Exchange) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.exchanges = append(rec.exchanges, exchange)
+	fmt.Fprintln(os.Stderr, i18n.T("lesson.record.exchange", exchange.Method, exchange.Path, exchange.StatusCode))
+}
+
+func (rec *recorder) recorded() []lesson.Exchange {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return rec.exchanges
+}
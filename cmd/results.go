@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/bootdotdev/bootdev/checks"
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/lesson"
+)
+
+// storedHTTPResult is an HTTP test result as the history and the queue
+// keep it. Unlike the payload sent to the API, it has the fields that
+// are only rendered.
+type storedHTTPResult struct {
+	Err            string            `json:"err,omitempty"`
+	RequestMethod  string            `json:"request_method"`
+	RequestURL     string            `json:"request_url"`
+	RequestHeaders http.Header       `json:"request_headers,omitempty"`
+	RequestBody    string            `json:"request_body,omitempty"`
+	ServerLogs     string            `json:"server_logs,omitempty"`
+	StatusCode     int               `json:"status_code"`
+	Headers        map[string]string `json:"headers,omitempty"`
+	BodyString     string            `json:"body"`
+}
+
+// storedCLIResult is a CLI command result as the history and the queue
+// keep it.
+type storedCLIResult struct {
+	ExitCode     int    `json:"exit_code"`
+	FinalCommand string `json:"final_command"`
+	Stdout       string `json:"stdout"`
+}
+
+// encodeResults encodes []checks.HttpTestResult or []api.CLICommandResult
+// to be stored.
+func encodeResults(results any) (json.RawMessage, error) {
+	switch results := results.(type) {
+	case []checks.HttpTestResult:
+		stored := make([]storedHTTPResult, len(results))
+		for i, result := range results {
+			stored[i] = storedHTTPResult(result)
+		}
+		return json.Marshal(stored)
+	case []api.CLICommandResult:
+		stored := make([]storedCLIResult, len(results))
+		for i, result := range results {
+			stored[i] = storedCLIResult(result)
+		}
+		return json.Marshal(stored)
+	default:
+		return nil, errors.New(i18n.T("submit.unsupported_type"))
+	}
+}
+
+// decodeResults decodes the stored results of lessonData, which are
+// []checks.HttpTestResult or []api.CLICommandResult depending on its
+// type. The lesson must have the data of its type, since that's what
+// the results are rendered with.
+func decodeResults(lessonData *api.Lesson, data []byte) (any, error) {
+	switch lessonData.Lesson.Type {
+	case lesson.TypeHTTPTests:
+		if lessonData.Lesson.LessonDataHTTPTests == nil {
+			return nil, errors.New(i18n.T("results.missing_data", lessonData.Lesson.Type))
+		}
+		var stored []storedHTTPResult
+		if err := json.Unmarshal(data, &stored); err != nil {
+			return nil, err
+		}
+		results := make([]checks.HttpTestResult, len(stored))
+		for i, result := range stored {
+			results[i] = checks.HttpTestResult(result)
+		}
+		return results, nil
+	case lesson.TypeCLICommand:
+		if lessonData.Lesson.LessonDataCLICommand == nil {
+			return nil, errors.New(i18n.T("results.missing_data", lessonData.Lesson.Type))
+		}
+		var stored []storedCLIResult
+		if err := json.Unmarshal(data, &stored); err != nil {
+			return nil, err
+		}
+		results := make([]api.CLICommandResult, len(stored))
+		for i, result := range stored {
+			results[i] = api.CLICommandResult(result)
+		}
+		return results, nil
+	default:
+		return nil, errors.New(i18n.T("submit.unsupported_type"))
+	}
+}
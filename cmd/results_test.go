@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bootdotdev/bootdev/checks"
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/lesson"
+)
+
+func TestResultsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		lessonType string
+		results    any
+	}{
+		{
+			name:       "http",
+			lessonType: lesson.TypeHTTPTests,
+			results: []checks.HttpTestResult{{
+				RequestMethod:  "POST",
+				RequestURL:     "http://localhost:8080/users",
+				RequestHeaders: http.Header{"Accept": {"application/json"}},
+				RequestBody:    `{"name": "boots"}`,
+				ServerLogs:     "listening on :8080\n",
+				StatusCode:     201,
+				Headers:        map[string]string{"Content-Type": "application/json"},
+				BodyString:     `{"id": 1}`,
+			}},
+		},
+		{
+			name:       "cli",
+			lessonType: lesson.TypeCLICommand,
+			results:    []api.CLICommandResult{{ExitCode: 1, FinalCommand: "echo boots", Stdout: "boots\n"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := encodeResults(tt.results)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var lessonData api.Lesson
+			lessonData.Lesson.Type = tt.lessonType
+			lessonData.Lesson.LessonDataHTTPTests = &api.LessonDataHTTPTests{}
+			lessonData.Lesson.LessonDataCLICommand = &api.LessonDataCLICommand{}
+			got, err := decodeResults(&lessonData, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the fields that are only rendered must survive too
+			if !reflect.DeepEqual(got, tt.results) {
+				t.Errorf("decodeResults() = %+v, want %+v", got, tt.results)
+			}
+		})
+	}
+}
+
+func TestDecodeResultsWithoutLessonData(t *testing.T) {
+	for _, lessonType := range []string{lesson.TypeHTTPTests, lesson.TypeCLICommand} {
+		var lessonData api.Lesson
+		lessonData.Lesson.Type = lessonType
+		if _, err := decodeResults(&lessonData, []byte("[]")); err == nil {
+			t.Errorf("decodeResults() of a %s lesson without its data succeeded", lessonType)
+		}
+	}
+}
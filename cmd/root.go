@@ -2,12 +2,16 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path"
-	"time"
+	"strings"
 
 	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/credentials"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/profile"
 	"github.com/bootdotdev/bootdev/version"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -15,11 +19,14 @@ import (
 
 var cfgFile string
 
+var profileName string
+
+const defaultBaseURL = "https://boot.dev"
+
 var rootCmd = &cobra.Command{
 	Use:   "bootdev",
-	Short: "The official boot.dev CLI",
-	Long: `The official CLI for boot.dev. This program is meant
-to be a companion app (not a replacement) for the website.`,
+	Short: i18n.T("root.short"),
+	Long:  i18n.T("root.long"),
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -34,7 +41,8 @@ func Execute(currentVersion string) error {
 
 func init() {
 	cobra.OnInitialize(initConfig)
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.bootdev.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", i18n.T("root.flag.config"))
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", i18n.T("root.flag.profile"))
 }
 
 func readViperConfig(paths []string) error {
@@ -49,23 +57,20 @@ func readViperConfig(paths []string) error {
 }
 
 func initConfig() {
-	viper.SetDefault("base_url", "https://boot.dev")
-	viper.SetDefault("api_url", "https://api.boot.dev")
-	viper.SetDefault("access_token", "")
-	viper.SetDefault("refresh_token", "")
-	viper.SetDefault("last_refresh", 0)
+	viper.SetDefault("credential_store", credentials.DefaultBackend)
+	readEnvTokens()
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
 		if err := viper.ReadInConfig(); err != nil {
-			fmt.Fprintf(os.Stderr, "Fehler beim Lesen der Konfigurationsdatei: %v\n", err)
+			fmt.Fprintln(os.Stderr, i18n.T("root.read_config_error", err))
 			os.Exit(1)
 		}
 	} else {
 		// Find home directory.
 		home, err := os.UserHomeDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Fehler beim Bestimmen des Home-Verzeichnisses: %v\n", err)
+			fmt.Fprintln(os.Stderr, i18n.T("root.home_dir_error", err))
 			os.Exit(1)
 		}
 
@@ -75,21 +80,51 @@ func initConfig() {
 		configPaths = append(configPaths, path.Join(home, ".config", "bootdev", "config.yaml"))
 		configPaths = append(configPaths, defaultPath)
 		if err := readViperConfig(configPaths); err != nil {
-			fmt.Fprintf(os.Stderr, "Fehler beim Lesen der Konfigurationsdateien: %v\n", err)
+			fmt.Fprintln(os.Stderr, i18n.T("root.read_configs_error", err))
 			if err := viper.SafeWriteConfigAs(defaultPath); err != nil {
-				fmt.Fprintf(os.Stderr, "Fehler beim Schreiben der Standard-Konfigurationsdatei: %v\n", err)
-				os.Exit(1)
-			}
-			viper.SetConfigFile(defaultPath)
-			if err := viper.ReadInConfig(); err != nil {
-				fmt.Fprintf(os.Stderr, "Fehler beim Lesen der Standard-Konfigurationsdatei: %v\n", err)
-				os.Exit(1)
+				fmt.Fprintln(os.Stderr, i18n.T("root.write_default_config_error", err))
+				// tokens from the environment don't need a config file
+				if !tokensFromEnv() {
+					os.Exit(1)
+				}
+			} else {
+				viper.SetConfigFile(defaultPath)
+				if err := viper.ReadInConfig(); err != nil {
+					fmt.Fprintln(os.Stderr, i18n.T("root.read_default_config_error", err))
+					os.Exit(1)
+				}
 			}
 		}
 	}
 
 	viper.SetEnvPrefix("bd")
+	// nested keys are set with underscores, e.g. BD_THEMES_DARK_COLORS_ACCENT
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
+
+	i18n.SetLocale(i18n.Detect(viper.GetString("locale")))
+
+	// The active profile is chosen with --profile, then BD_PROFILE, then
+	// 'bootdev profile use'. The config key has a different name than the
+	// env var so that writing the config never persists BD_PROFILE.
+	name := profileName
+	if name == "" {
+		name = os.Getenv("BD_PROFILE")
+	}
+	if name == "" {
+		name = viper.GetString("active_profile")
+	}
+	profile.SetActive(name)
+	if !profile.Exists(profile.Active()) {
+		fmt.Fprintln(os.Stderr, i18n.T("profile.unknown", profile.Active(), strings.Join(profile.Names(), ", ")))
+		os.Exit(1)
+	}
+	viper.SetDefault(profile.Key("base_url"), defaultBaseURL)
+	viper.SetDefault(profile.Key("api_url"), api.DefaultBaseURL)
+	viper.SetDefault(profile.Key("access_token"), "")
+	viper.SetDefault(profile.Key("refresh_token"), "")
+	viper.SetDefault(profile.Key("last_refresh"), 0)
+	viper.SetDefault(profile.Key("access_token_expires"), 0)
 }
 
 // Chain multiple commands together.
@@ -105,8 +140,13 @@ func compose(commands ...func(cmd *cobra.Command, args []string)) func(cmd *cobr
 // if you want to require the user to update their CLI first.
 func requireUpdated(cmd *cobra.Command, args []string) {
 	info := version.FromContext(cmd.Context())
+	if info != nil && errors.Is(info.FailedToFetch, version.ErrUnreachable) {
+		// offline, the command may still work from the cache or queue
+		fmt.Fprintln(os.Stderr, i18n.T("root.update_check_offline"))
+		return
+	}
 	if info == nil || info.FailedToFetch != nil {
-		fmt.Fprintln(os.Stderr, "Failed to fetch update info. Are you online?")
+		fmt.Fprintln(os.Stderr, i18n.T("root.update_info_error"))
 		os.Exit(1)
 	}
 	if info.IsUpdateRequired {
@@ -122,31 +162,39 @@ func requireUpdated(cmd *cobra.Command, args []string) {
 func requireAuth(cmd *cobra.Command, args []string) {
 	promptLoginAndExitIf := func(condition bool) {
 		if condition {
-			fmt.Fprintln(os.Stderr, "You must be logged in to use that command.")
-			fmt.Fprintln(os.Stderr, "Please run 'bootdev login' first.")
+			fmt.Fprintln(os.Stderr, i18n.T("root.login_required"))
+			fmt.Fprintln(os.Stderr, i18n.T("root.login_prompt"))
 			os.Exit(1)
 		}
 	}
 
-	access_token := viper.GetString("access_token")
-	promptLoginAndExitIf(access_token == "")
+	token, err := configTokens{}.Token()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	// a refresh token alone is enough, it's exchanged right away
+	promptLoginAndExitIf(token.AccessToken == "" && token.RefreshToken == "")
 
 	// We only refresh if our token is getting stale.
-	last_refresh := viper.GetInt64("last_refresh")
-	if time.Now().Add(-time.Minute*55).Unix() <= last_refresh {
+	if !accessTokenIsStale() {
+		authenticated = true
 		return
 	}
 
-	creds, err := api.FetchAccessToken()
+	creds, err := newClient().FetchAccessToken(cmd.Context())
+	if errors.Is(err, api.ErrNetwork) {
+		// the tokens are refreshed once the API can be reached again,
+		// until then submissions are queued
+		fmt.Fprintln(os.Stderr, i18n.T("root.refresh_offline"))
+		return
+	}
 	promptLoginAndExitIf(err != nil)
 	if creds.AccessToken == "" || creds.RefreshToken == "" {
 		promptLoginAndExitIf(err != nil)
 	}
 
-	viper.Set("access_token", creds.AccessToken)
-	viper.Set("refresh_token", creds.RefreshToken)
-	viper.Set("last_refresh", time.Now().Unix())
-
-	err = viper.WriteConfig()
+	err = configTokens{}.SetToken(api.Token{AccessToken: creds.AccessToken, RefreshToken: creds.RefreshToken})
 	promptLoginAndExitIf(err != nil)
+	authenticated = true
 }
@@ -1,20 +1,150 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+
+	"github.com/bootdotdev/bootdev/checks"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/lesson"
+	"github.com/bootdotdev/bootdev/render"
+	"github.com/bootdotdev/bootdev/watch"
 	"github.com/spf13/cobra"
 )
 
+var offline bool
+
+var lessonFile string
+
+var watchPaths []string
+
 func init() {
 	rootCmd.AddCommand(runCmd)
-	runCmd.Flags().StringVarP(&submitBaseURL, "baseurl", "b", "", "set the base URL for HTTP tests, overriding any default")
-	runCmd.Flags().BoolVarP(&forceSubmit, "submit", "s", false, "shortcut flag to submit instead of run")
+	runCmd.Flags().StringVarP(&submitBaseURL, "baseurl", "b", "", i18n.T("submit.flag.baseurl"))
+	runCmd.Flags().BoolVarP(&forceSubmit, "submit", "s", false, i18n.T("run.flag.submit"))
+	runCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, i18n.T("submit.flag.interactive"))
+	runCmd.Flags().IntVar(&diffContext, "diff-context", render.DefaultDiffContext, i18n.T("submit.flag.diff_context"))
+	runCmd.Flags().BoolVar(&offline, "offline", false, i18n.T("run.flag.offline"))
+	runCmd.Flags().StringVarP(&lessonFile, "file", "f", "", i18n.T("run.flag.file"))
+	runCmd.Flags().StringSliceVarP(&watchPaths, "watch", "w", nil, i18n.T("run.flag.watch"))
+	runCmd.Flags().Lookup("watch").NoOptDefVal = "."
+	runCmd.Flags().StringVar(&serveCommand, "serve", "", i18n.T("run.flag.serve"))
+	runCmd.MarkFlagsMutuallyExclusive("offline", "submit")
+	runCmd.MarkFlagsMutuallyExclusive("watch", "submit")
+	runCmd.MarkFlagsMutuallyExclusive("watch", "interactive")
+	runCmd.MarkFlagsMutuallyExclusive("file", "submit")
+	runCmd.MarkFlagsMutuallyExclusive("file", "offline")
 }
 
 // runCmd represents the run command
 var runCmd = &cobra.Command{
-	Use:    "run UUID",
-	Args:   cobra.MatchAll(cobra.RangeArgs(1, 10)),
-	Short:  "Run a lesson without submitting",
-	PreRun: compose(requireUpdated, requireAuth),
+	Use:   "run [UUID|SLUG]",
+	Short: i18n.T("run.short"),
+	Args: func(cmd *cobra.Command, args []string) error {
+		// a lesson from a file has no UUID, all args are for its commands
+		if lessonFile != "" {
+			return cobra.MaximumNArgs(9)(cmd, args)
+		}
+		return cobra.RangeArgs(0, 10)(cmd, args)
+	},
+	PreRun: unlessLocal(compose(requireUpdated, requireAuth)),
 	RunE:   submissionHandler,
 }
+
+// unlessLocal skips checks that need the network when running offline
+// or running a lesson from a file.
+func unlessLocal(command func(cmd *cobra.Command, args []string)) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if !offline && lessonFile == "" {
+			command(cmd, args)
+		}
+	}
+}
+
+// runLessonFile runs a lesson from a file and checks the results itself,
+// since a lesson that isn't published can't be submitted.
+func runLessonFile(path string, args []string) error {
+	lessonData, err := lesson.Load(path)
+	if err != nil {
+		return err
+	}
+	if err := lesson.Validate(lessonData); err != nil {
+		return errors.New(i18n.T("run.file_invalid", path, err))
+	}
+	switch lessonData.Lesson.Type {
+	case lesson.TypeHTTPTests:
+		results, err := runHTTPTests(*lessonData)
+		if err != nil {
+			return err
+		}
+		data := *lessonData.Lesson.LessonDataHTTPTests
+		failure := checks.EvaluateHTTPTests(data, results)
+		render.HTTPLocal(data, results, failure, diffContext)
+		recordRun(withHTTPVerdict(lessonFileRun(path), failure), lessonData, results)
+		if interactive {
+			render.HTTPExplore(data, results, failure, true, diffContext)
+		}
+	case lesson.TypeCLICommand:
+		results := checks.CLICommand(*lessonData, args)
+		data := *lessonData.Lesson.LessonDataCLICommand
+		failure := checks.EvaluateCLICommand(data, results)
+		render.CommandLocal(data, results, failure, diffContext)
+		recordRun(withCLIVerdict(lessonFileRun(path), failure), lessonData, results)
+		if interactive {
+			render.CommandExplore(data, results, failure, true, diffContext)
+		}
+	}
+	return nil
+}
+
+// watchLesson runs the lesson, then again each time files change, until
+// it's interrupted.
+func watchLesson(cmd *cobra.Command, args []string) error {
+	paths := slices.Clone(watchPaths)
+	if lessonFile != "" {
+		paths = append(paths, lessonFile)
+	}
+	watcher, err := watch.New(paths, watch.DefaultDelay)
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	for {
+		// a run can fail while files are half edited, the next change may fix it
+		if err := runLesson(cmd, args); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("run.watch.error", err))
+		}
+		render.Watching(paths)
+		changed, err := watcher.Wait(ctx)
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		render.Rerun(relativePaths(changed))
+	}
+}
+
+func relativePaths(paths []string) []string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return paths
+	}
+	relative := make([]string, len(paths))
+	for i, path := range paths {
+		if rel, err := filepath.Rel(wd, path); err == nil {
+			path = rel
+		}
+		relative[i] = path
+	}
+	return relative
+}
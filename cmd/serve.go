@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/bootdotdev/bootdev/checks"
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+)
+
+// serveTimeout is how long a server gets to start. It's generous since
+// commands like go run build the server first.
+const serveTimeout = 60 * time.Second
+
+// stopTimeout is how long a server gets to shut down before it's killed.
+const stopTimeout = 5 * time.Second
+
+var serveCommand string
+
+// server is the user's server, started for the HTTP tests of a run.
+type server struct {
+	cmd  *exec.Cmd
+	logs *serverLogs
+	done chan struct{}
+	err  error
+	// gone is closed once no process of the group is left. Its ID can
+	// belong to another group after that, which mustn't be signalled.
+	gone chan struct{}
+}
+
+// startServer runs command in a shell, in a process group of its own so
+// that the processes it starts are stopped along with it, and waits
+// until baseURL answers.
+func startServer(command string, baseURL string) (*server, error) {
+	// the tests would quietly run against whatever already listens there
+	if err := portFree(baseURL); err != nil {
+		return nil, err
+	}
+	cmd := shellCommand(command)
+	logs := &serverLogs{}
+	cmd.Stdout = logs
+	cmd.Stderr = logs
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	s := &server{cmd: cmd, logs: logs, done: make(chan struct{}), gone: make(chan struct{})}
+	go s.wait()
+
+	deadline := time.After(serveTimeout)
+	for {
+		if ready(baseURL) {
+			return s, nil
+		}
+		select {
+		case <-s.done:
+			// whatever the shell started in the background goes too
+			s.stop()
+			return nil, errors.New(i18n.T("run.serve.exited", command, s.err, logs.Since(0)))
+		case <-deadline:
+			s.stop()
+			return nil, errors.New(i18n.T("run.serve.timeout", command, baseURL, serveTimeout, logs.Since(0)))
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func shellCommand(command string) *exec.Cmd {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	return cmd
+}
+
+// portFree fails if something is already listening where the server is
+// supposed to. Only local addresses are checked, since a remote host's
+// ports can't be bound here to find out.
+func portFree(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+	if !isLoopback(u.Hostname()) {
+		return nil
+	}
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	address := net.JoinHostPort(u.Hostname(), port)
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return errors.New(i18n.T("run.serve.port_in_use", address))
+	}
+	return listener.Close()
+}
+
+func isLoopback(host string) bool {
+	if host == "" || host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ready is true once the server answers, whatever the answer is.
+func ready(baseURL string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return true
+}
+
+// wait waits for the shell to exit, and then for the rest of its group.
+func (s *server) wait() {
+	s.err = s.cmd.Wait()
+	close(s.done)
+	// the shell's ID is free for reuse now, but the group lives on while
+	// what it started runs, so it's watched until it's gone
+	for groupAlive(s.cmd) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	close(s.gone)
+}
+
+// stop asks the server's process group to shut down, and kills it if it
+// doesn't in time. The group is signalled even when the shell already
+// exited, since the server it started may still be running.
+func (s *server) stop() {
+	s.signal(syscall.SIGTERM)
+	select {
+	case <-s.gone:
+	case <-time.After(stopTimeout):
+		s.signal(syscall.SIGKILL)
+		<-s.gone
+	}
+	<-s.done
+}
+
+// signal signals the whole process group, so that the server a command
+// like go run started gets it too, not just the command. A group that's
+// gone is left alone.
+func (s *server) signal(sig syscall.Signal) {
+	select {
+	case <-s.gone:
+	default:
+		syscall.Kill(-s.cmd.Process.Pid, sig)
+	}
+}
+
+// groupAlive reports whether any process of the group is left.
+func groupAlive(cmd *exec.Cmd) bool {
+	return syscall.Kill(-cmd.Process.Pid, 0) == nil
+}
+
+// serverLogs collects the output of a server. It implements checks.Logs.
+type serverLogs struct {
+	mu      sync.Mutex
+	logs    []byte
+	written time.Time
+}
+
+func (l *serverLogs) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logs = append(l.logs, p...)
+	l.written = time.Now()
+	return len(p), nil
+}
+
+func (l *serverLogs) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.logs)
+}
+
+// Since waits for the server to stop logging for a moment first, since
+// servers often log a request only after they sent the response, and
+// the output takes a moment to arrive through the pipe anyway.
+func (l *serverLogs) Since(n int) string {
+	for i := 0; i < 10; i++ {
+		time.Sleep(20 * time.Millisecond)
+		l.mu.Lock()
+		quiet := time.Since(l.written) > 20*time.Millisecond
+		l.mu.Unlock()
+		if quiet {
+			break
+		}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if n > len(l.logs) {
+		return ""
+	}
+	return strings.TrimSpace(string(l.logs[n:]))
+}
+
+// runHTTPTests runs the HTTP tests of a lesson, against a server started
+// for them with --serve if there is one.
+func runHTTPTests(lesson api.Lesson) ([]checks.HttpTestResult, error) {
+	if serveCommand == "" {
+		results, _ := checks.HttpTest(lesson, &submitBaseURL)
+		return results, nil
+	}
+	baseURL := submitBaseURL
+	if baseURL == "" && lesson.Lesson.LessonDataHTTPTests.HttpTests.BaseURL != nil {
+		baseURL = *lesson.Lesson.LessonDataHTTPTests.HttpTests.BaseURL
+	}
+	if baseURL == "" {
+		return nil, errors.New(i18n.T("checks.no_base_url"))
+	}
+	srv, err := startServer(serveCommand, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	defer srv.stop()
+	results, _ := checks.HttpTestWithLogs(lesson, &submitBaseURL, srv.logs)
+	return results, nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestPortFree(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	tests := []struct {
+		name    string
+		baseURL string
+		wantErr bool
+	}{
+		{"port in use", "http://127.0.0.1:" + strconv.Itoa(port), true},
+		{"localhost", "http://localhost:" + strconv.Itoa(port), true},
+		// a remote host's ports can't be bound here, which isn't them being in use
+		{"remote host", "http://192.0.2.1:" + strconv.Itoa(port), false},
+		{"remote name", "http://example.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := portFree(tt.baseURL); (err != nil) != tt.wantErr {
+				t.Errorf("portFree(%s) = %v, want error %v", tt.baseURL, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStopAfterShellExited(t *testing.T) {
+	cmd := shellCommand("sleep 30 & exit 0")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	s := &server{cmd: cmd, done: make(chan struct{}), gone: make(chan struct{})}
+	go s.wait()
+	<-s.done
+
+	start := time.Now()
+	s.stop()
+	if elapsed := time.Since(start); elapsed >= stopTimeout {
+		t.Errorf("stop() took %s, the background process should have stopped on SIGTERM", elapsed)
+	}
+	if groupAlive(cmd) {
+		t.Error("the process the shell started is still running")
+	}
+	// the group is gone and its ID may be another's now
+	s.stop()
+}
@@ -5,29 +5,54 @@ import (
 
 	"github.com/bootdotdev/bootdev/checks"
 	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/history"
+	"github.com/bootdotdev/bootdev/i18n"
 	"github.com/bootdotdev/bootdev/render"
 	"github.com/spf13/cobra"
 )
 
 var submitBaseURL string
 var forceSubmit bool
+var diffContext int
+var interactive bool
 
 func init() {
 	rootCmd.AddCommand(submitCmd)
-	submitCmd.Flags().StringVarP(&submitBaseURL, "baseurl", "b", "", "set the base URL for HTTP tests, overriding any default")
+	submitCmd.Flags().StringVarP(&submitBaseURL, "baseurl", "b", "", i18n.T("submit.flag.baseurl"))
+	submitCmd.Flags().IntVar(&diffContext, "diff-context", render.DefaultDiffContext, i18n.T("submit.flag.diff_context"))
+	submitCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, i18n.T("submit.flag.interactive"))
 }
 
 // submitCmd represents the submit command
 var submitCmd = &cobra.Command{
-	Use:    "submit UUID",
-	Args:   cobra.MatchAll(cobra.RangeArgs(1, 10)),
-	Short:  "Submit a lesson",
+	Use:    "submit [UUID|SLUG]",
+	Args:   cobra.MatchAll(cobra.RangeArgs(0, 10)),
+	Short:  i18n.T("submit.short"),
 	PreRun: compose(requireUpdated, requireAuth),
 	RunE:   submissionHandler,
 }
 
 func submissionHandler(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
+	if lessonFile == "" {
+		// resolved once, not again on every rerun
+		resolved, err := resolveLesson(cmd, args)
+		if err != nil {
+			return err
+		}
+		args = resolved
+	}
+	if len(watchPaths) > 0 {
+		return watchLesson(cmd, args)
+	}
+	return runLesson(cmd, args)
+}
+
+// runLesson runs a lesson once, and submits it if asked to.
+func runLesson(cmd *cobra.Command, args []string) error {
+	if lessonFile != "" {
+		return runLessonFile(lessonFile, args)
+	}
 	isSubmit := cmd.Name() == "submit" || forceSubmit
 	lessonUUID := args[0]
 	optionalPositionalArgs := []string{}
@@ -35,37 +60,87 @@ func submissionHandler(cmd *cobra.Command, args []string) error {
 		optionalPositionalArgs = args[1:]
 	}
 
-	lesson, err := api.FetchLesson(lessonUUID)
+	client := newClient()
+	var lesson *api.Lesson
+	var err error
+	if offline {
+		lesson, err = client.CachedLesson(lessonUUID)
+	} else {
+		lesson, err = client.FetchLesson(cmd.Context(), lessonUUID)
+	}
+	if errors.Is(err, api.ErrNotCached) {
+		return errors.New(i18n.T("submit.not_cached", lessonUUID))
+	}
+	if errors.Is(err, api.ErrNotFound) {
+		return errors.New(i18n.T("submit.lesson_not_found", lessonUUID))
+	}
 	if err != nil {
-		return err
+		return explainAPIError(err)
 	}
 	switch lesson.Lesson.Type {
 	case "type_http_tests":
-		results, _ := checks.HttpTest(*lesson, &submitBaseURL)
+		results, err := runHTTPTests(*lesson)
+		if err != nil {
+			return err
+		}
 		data := *lesson.Lesson.LessonDataHTTPTests
 		if isSubmit {
-			failure, err := api.SubmitHTTPTestLesson(lessonUUID, results)
+			submission, err := newSubmission(lessonUUID, lesson, results)
 			if err != nil {
 				return err
 			}
-			render.HTTPSubmission(data, results, failure)
+			ctx := api.WithIdempotencyKey(cmd.Context(), submission.ID)
+			failure, err := client.SubmitHTTPTestPayload(ctx, lessonUUID, submission.Payload)
+			if errors.Is(err, api.ErrNetwork) {
+				render.HTTPRun(data, results)
+				return queueSubmission(submission)
+			}
+			if err != nil {
+				return explainAPIError(err)
+			}
+			render.HTTPSubmission(data, results, failure, diffContext)
+			recordRun(withHTTPVerdict(history.Run{LessonUUID: lessonUUID, Mode: history.ModeSubmit}, failure), lesson, results)
+			if interactive {
+				render.HTTPExplore(data, results, failure, true, diffContext)
+			}
 		} else {
 			render.HTTPRun(data, results)
+			recordRun(history.Run{LessonUUID: lessonUUID, Mode: history.ModeRun}, lesson, results)
+			if interactive {
+				render.HTTPExplore(data, results, nil, false, diffContext)
+			}
 		}
 	case "type_cli_command":
 		results := checks.CLICommand(*lesson, optionalPositionalArgs)
 		data := *lesson.Lesson.LessonDataCLICommand
 		if isSubmit {
-			failure, err := api.SubmitCLICommandLesson(lessonUUID, results)
+			submission, err := newSubmission(lessonUUID, lesson, results)
 			if err != nil {
 				return err
 			}
-			render.CommandSubmission(data, results, failure)
+			ctx := api.WithIdempotencyKey(cmd.Context(), submission.ID)
+			failure, err := client.SubmitCLICommandPayload(ctx, lessonUUID, submission.Payload)
+			if errors.Is(err, api.ErrNetwork) {
+				render.CommandRun(data, results)
+				return queueSubmission(submission)
+			}
+			if err != nil {
+				return explainAPIError(err)
+			}
+			render.CommandSubmission(data, results, failure, diffContext)
+			recordRun(withCLIVerdict(history.Run{LessonUUID: lessonUUID, Mode: history.ModeSubmit}, failure), lesson, results)
+			if interactive {
+				render.CommandExplore(data, results, failure, true, diffContext)
+			}
 		} else {
 			render.CommandRun(data, results)
+			recordRun(history.Run{LessonUUID: lessonUUID, Mode: history.ModeRun}, lesson, results)
+			if interactive {
+				render.CommandExplore(data, results, nil, false, diffContext)
+			}
 		}
 	default:
-		return errors.New("unsupported lesson type")
+		return errors.New(i18n.T("submit.unsupported_type"))
 	}
 	return nil
 }
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bootdotdev/bootdev/cache"
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/profile"
+	"github.com/bootdotdev/bootdev/version"
+	"github.com/spf13/viper"
+)
+
+func TestSubmitQueuesWhenOffline(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	// nothing listens at the API's address anymore
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	viper.Set(profile.Key("api_url"), server.URL)
+	viper.Set("max_attempts", 1)
+	viper.Set("accessible", true)
+	// a refresh token alone makes requireAuth refresh the tokens first
+	envToken = api.Token{RefreshToken: "refresh"}
+	tokenCache.token = nil
+	t.Cleanup(func() {
+		viper.Reset()
+		envToken = api.Token{}
+		tokenCache.token = nil
+		authenticated, queuedThisRun = false, false
+	})
+
+	const lessonUUID = "4e3d1b9a-7f0c-4b8e-9a51-2c6d8f0e1a37"
+	lessons, err := cache.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = lessons.Put(lessonUUID, api.CachedLesson{Data: []byte(`{"Lesson": {
+		"Type": "type_cli_command",
+		"LessonDataCLICommand": {"CLICommandData": {"Commands": [
+			{"Command": "echo hello", "Tests": [{"StdoutContainsAll": ["hello"]}]}
+		]}}
+	}}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := version.VersionInfo{FailedToFetch: version.ErrUnreachable}
+	submitCmd.SetContext(version.WithContext(context.Background(), &info))
+	args := []string{lessonUUID}
+	requireUpdated(submitCmd, args)
+	requireAuth(submitCmd, args)
+	if err := runLesson(submitCmd, args); err != nil {
+		t.Fatalf("runLesson() = %v, want the submission queued", err)
+	}
+
+	submissions, err := queuedSubmissions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(submissions) != 1 || submissions[0].LessonUUID != lessonUUID {
+		t.Fatalf("queued %+v, want one submission of %s", submissions, lessonUUID)
+	}
+	if authenticated {
+		t.Error("authenticated after the refresh failed, queued submissions would be sent along")
+	}
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bootdotdev/bootdev/checks"
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/history"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/profile"
+	"github.com/bootdotdev/bootdev/queue"
+	"github.com/bootdotdev/bootdev/render"
+	"github.com/spf13/cobra"
+)
+
+// authenticated is set once requireAuth passed, i.e. when the API was
+// reachable a moment ago, and queuedThisRun when it wasn't after all.
+// Queued submissions are only sent along with other commands then.
+var authenticated, queuedThisRun bool
+
+var syncCmd = &cobra.Command{
+	Use:          "sync",
+	Short:        i18n.T("sync.short"),
+	Long:         i18n.T("sync.long"),
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	PreRun:       compose(requireUpdated, requireAuth),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		submissions, err := queuedSubmissions()
+		if err != nil {
+			return err
+		}
+		if len(submissions) == 0 {
+			fmt.Println(i18n.T("sync.empty"))
+			return nil
+		}
+		return syncSubmissions(cmd.Context(), submissions)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.PersistentPostRun = func(cmd *cobra.Command, args []string) {
+		if !syncsAfter(cmd) {
+			return
+		}
+		submissions, err := queuedSubmissions()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("sync.list_error", err))
+			return
+		}
+		if len(submissions) == 0 {
+			return
+		}
+		fmt.Println()
+		// failing to send them is for 'bootdev sync' to explain, the
+		// command that was run worked
+		syncSubmissions(cmd.Context(), submissions)
+	}
+}
+
+// syncsAfter reports whether queued submissions are sent along after
+// cmd, which only makes sense once the API was reached with tokens that
+// are still around. Commands that manage the tokens are left alone.
+func syncsAfter(cmd *cobra.Command) bool {
+	if cmd == syncCmd || cmd == logoutCmd || !authenticated || queuedThisRun {
+		return false
+	}
+	for parent := cmd; parent != nil; parent = parent.Parent() {
+		if parent == authCmd {
+			return false
+		}
+	}
+	token, err := configTokens{}.Token()
+	return err == nil && (token.AccessToken != "" || token.RefreshToken != "")
+}
+
+// submissionQueue opens the queue of the active profile. Each profile
+// gets its own, since they're sent with the profile's tokens.
+func submissionQueue() (*queue.Queue, error) {
+	q, err := queue.Open()
+	if err != nil {
+		return nil, err
+	}
+	return queue.New(profile.Dir(q.Dir())), nil
+}
+
+// queuedSubmissions returns the submissions to send. The ones that
+// can't be read are reported, they'd never go through.
+func queuedSubmissions() ([]queue.Submission, error) {
+	q, err := submissionQueue()
+	if err != nil {
+		return nil, err
+	}
+	submissions, unreadable, err := q.List()
+	for _, u := range unreadable {
+		fmt.Fprintln(os.Stderr, i18n.T("sync.unreadable", u.Path, u.Err))
+	}
+	return submissions, err
+}
+
+// newSubmission prepares the submission of results, so that it can be
+// queued and later sent exactly as it would be sent now.
+func newSubmission(lessonUUID string, lesson *api.Lesson, results any) (queue.Submission, error) {
+	var payload []byte
+	var err error
+	switch results := results.(type) {
+	case []checks.HttpTestResult:
+		payload, err = api.HTTPTestPayload(results)
+	case []api.CLICommandResult:
+		payload, err = api.CLICommandPayload(results)
+	default:
+		err = errors.New(i18n.T("submit.unsupported_type"))
+	}
+	if err != nil {
+		return queue.Submission{}, err
+	}
+	lessonData, err := json.Marshal(lesson)
+	if err != nil {
+		return queue.Submission{}, err
+	}
+	stored, err := encodeResults(results)
+	if err != nil {
+		return queue.Submission{}, err
+	}
+	return queue.Submission{
+		ID:         api.NewIdempotencyKey(),
+		LessonUUID: lessonUUID,
+		QueuedAt:   time.Now(),
+		Payload:    payload,
+		Lesson:     lessonData,
+		Results:    stored,
+	}, nil
+}
+
+// queueSubmission keeps a submission that couldn't reach the API.
+func queueSubmission(submission queue.Submission) error {
+	q, err := submissionQueue()
+	if err != nil {
+		return err
+	}
+	if err := q.Add(submission); err != nil {
+		return err
+	}
+	queuedThisRun = true
+	fmt.Println(i18n.T("sync.queued"))
+	return nil
+}
+
+// syncSubmissions sends queued submissions, oldest first, and renders
+// the verdict of each. It stops at the first one that can't be sent
+// for a reason that would stop the rest too, like being offline.
+func syncSubmissions(ctx context.Context, submissions []queue.Submission) error {
+	q, err := submissionQueue()
+	if err != nil {
+		return err
+	}
+	client := newClient()
+	fmt.Println(i18n.T("sync.sending", len(submissions)))
+	for _, submission := range submissions {
+		fmt.Println(i18n.T("sync.submission", submission.LessonUUID, submission.QueuedAt.Local().Format(time.DateTime)))
+		err := sendSubmission(ctx, client, submission)
+		switch {
+		case errors.Is(err, api.ErrNetwork), errors.Is(err, api.ErrServer),
+			errors.Is(err, api.ErrRateLimited), errors.Is(err, api.ErrUnauthorized),
+			errors.Is(err, context.Canceled):
+			return explainAPIError(err)
+		case err != nil:
+			// the API won't ever take this one, so it's dropped
+			fmt.Fprintln(os.Stderr, i18n.T("sync.dropped", explainAPIError(err)))
+		}
+		if err := q.Remove(submission.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendSubmission sends a queued submission and renders the verdict.
+func sendSubmission(ctx context.Context, client *api.Client, submission queue.Submission) error {
+	var lesson api.Lesson
+	if err := json.Unmarshal(submission.Lesson, &lesson); err != nil {
+		return err
+	}
+	ctx = api.WithIdempotencyKey(ctx, submission.ID)
+	results, err := decodeResults(&lesson, submission.Results)
+	if err != nil {
+		return err
+	}
+	switch rendered := results.(type) {
+	case []checks.HttpTestResult:
+		failure, err := client.SubmitHTTPTestPayload(ctx, submission.LessonUUID, submission.Payload)
+		if err != nil {
+			return err
+		}
+		render.HTTPSubmission(*lesson.Lesson.LessonDataHTTPTests, rendered, failure, render.DefaultDiffContext)
+		recordRun(withHTTPVerdict(history.Run{LessonUUID: submission.LessonUUID, Mode: history.ModeSubmit}, failure), &lesson, rendered)
+	case []api.CLICommandResult:
+		failure, err := client.SubmitCLICommandPayload(ctx, submission.LessonUUID, submission.Payload)
+		if err != nil {
+			return err
+		}
+		render.CommandSubmission(*lesson.Lesson.LessonDataCLICommand, rendered, failure, render.DefaultDiffContext)
+		recordRun(withCLIVerdict(history.Run{LessonUUID: submission.LessonUUID, Mode: history.ModeSubmit}, failure), &lesson, rendered)
+	default:
+		return errors.New(i18n.T("submit.unsupported_type"))
+	}
+	return nil
+}
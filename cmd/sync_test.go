@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/spf13/cobra"
+)
+
+func TestSyncsAfter(t *testing.T) {
+	t.Cleanup(func() {
+		authenticated = false
+		tokenCache.token = nil
+	})
+	tokens := api.Token{AccessToken: "access", RefreshToken: "refresh"}
+	tests := []struct {
+		name  string
+		cmd   *cobra.Command
+		token api.Token
+		want  bool
+	}{
+		{"submit", submitCmd, tokens, true},
+		{"refresh token only", submitCmd, api.Token{RefreshToken: "refresh"}, true},
+		{"no tokens", submitCmd, api.Token{}, false},
+		{"sync", syncCmd, tokens, false},
+		{"logout", logoutCmd, tokens, false},
+		{"auth subcommand", authStatusCmd, tokens, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authenticated, queuedThisRun = true, false
+			token := tt.token
+			tokenCache.token = &token
+			if got := syncsAfter(tt.cmd); got != tt.want {
+				t.Errorf("syncsAfter(%s) = %v, want %v", tt.cmd.Name(), got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"regexp"
 
+	"github.com/bootdotdev/bootdev/i18n"
 	"github.com/bootdotdev/bootdev/version"
 	"github.com/spf13/cobra"
 )
@@ -13,19 +14,20 @@ import (
 var upgradeCmd = &cobra.Command{
 	Use:     "upgrade",
 	Aliases: []string{"update"},
-	Short:   "Installs the latest version of the CLI.",
+	Short:   i18n.T("upgrade.short"),
 	Run: func(cmd *cobra.Command, args []string) {
 		info := version.FromContext(cmd.Context())
 		if !info.IsOutdated {
-			fmt.Println("Boot.dev CLI is already up to date.")
+			fmt.Println(i18n.T("upgrade.up_to_date"))
 			return
 		}
 		// Install the latest version
 		command := exec.Command("go", "install", "github.com/bootdotdev/bootdev@latest")
 		output, err := command.CombinedOutput()
 		if err != nil {
-			fmt.Printf("Error installing latest version: %s\n", err)
-			fmt.Printf("Command output: %s\n", string(output)) // Optional: Protokolliere die Ausgabe zur Fehlerbehebung
+			fmt.Println(i18n.T("upgrade.install_error", err))
+			// the output of go install is the most useful thing for debugging
+			fmt.Println(i18n.T("upgrade.command_output", string(output)))
 			cobra.CheckErr(err)
 		}
 
@@ -35,7 +37,7 @@ var upgradeCmd = &cobra.Command{
 		cobra.CheckErr(err)
 		re := regexp.MustCompile(`v\d+\.\d+\.\d+`)
 		version := re.FindString(string(versionOutput))
-		fmt.Printf("Successfully upgraded to %s!\n", version)
+		fmt.Println(i18n.T("upgrade.success", version))
 
 		os.Exit(0)
 	},
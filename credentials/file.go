@@ -0,0 +1,161 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/fsutil"
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt parameters recommended for interactive logins
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 16
+)
+
+// encryptedFile is what's written to disk. The plaintext is the JSON
+// of a map from account to tokens, sealed with AES-256-GCM under a key
+// derived from the passphrase with scrypt.
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// fileStore keeps the tokens in a file encrypted with a passphrase.
+// The passphrase is asked for once and then remembered.
+type fileStore struct {
+	path       string
+	account    string
+	passphrase func(confirm bool) (string, error)
+
+	key  []byte
+	salt []byte
+}
+
+func (s *fileStore) Load() (api.Token, error) {
+	accounts, err := s.read()
+	if err != nil {
+		return api.Token{}, err
+	}
+	return accounts[s.account], nil
+}
+
+func (s *fileStore) Save(token api.Token) error {
+	accounts, err := s.read()
+	if err != nil {
+		return err
+	}
+	accounts[s.account] = token
+	return s.write(accounts)
+}
+
+func (s *fileStore) Delete() error {
+	accounts, err := s.read()
+	if err != nil {
+		return err
+	}
+	delete(accounts, s.account)
+	return s.write(accounts)
+}
+
+func (s *fileStore) read() (map[string]api.Token, error) {
+	accounts := map[string]api.Token{}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return accounts, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if s.key == nil {
+		passphrase, err := s.passphrase(false)
+		if err != nil {
+			return nil, err
+		}
+		s.key, err = deriveKey(passphrase, file.Salt)
+		if err != nil {
+			return nil, err
+		}
+		s.salt = file.Salt
+	}
+	gcm, err := newGCM(s.key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		s.key = nil
+		return nil, ErrPassphrase
+	}
+	err = json.Unmarshal(plaintext, &accounts)
+	return accounts, err
+}
+
+func (s *fileStore) write(accounts map[string]api.Token) error {
+	if s.key == nil {
+		passphrase, err := s.passphrase(true)
+		if err != nil {
+			return err
+		}
+		s.salt = make([]byte, saltLen)
+		if _, err := rand.Read(s.salt); err != nil {
+			return err
+		}
+		s.key, err = deriveKey(passphrase, s.salt)
+		if err != nil {
+			return err
+		}
+	}
+	gcm, err := newGCM(s.key)
+	if err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(accounts)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data, err := json.Marshal(encryptedFile{
+		Version:    1,
+		Salt:       s.salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return err
+	}
+
+	return fsutil.WriteFileAtomic(s.path, data, 0o600)
+}
+
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
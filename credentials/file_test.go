@@ -0,0 +1,86 @@
+package credentials
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	api "github.com/bootdotdev/bootdev/client"
+)
+
+func TestEncryptedFile_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	passphrase := func(confirm bool) (string, error) {
+		return "correct horse battery staple", nil
+	}
+	token := api.Token{AccessToken: "mockAccessToken", RefreshToken: "mockRefreshToken"}
+
+	store, err := Open(EncryptedFile, Options{Path: path, Passphrase: passphrase})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := store.Save(token); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Contains(string(data), "mockAccessToken") || strings.Contains(string(data), "mockRefreshToken") {
+		t.Error("Expected the tokens to be encrypted on disk")
+	}
+
+	reopened, _ := Open(EncryptedFile, Options{Path: path, Passphrase: passphrase})
+	loaded, err := reopened.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if loaded != token {
+		t.Errorf("Expected %v, got %v", token, loaded)
+	}
+}
+
+func TestEncryptedFile_WrongPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	store, _ := Open(EncryptedFile, Options{Path: path, Passphrase: func(bool) (string, error) {
+		return "right", nil
+	}})
+	if err := store.Save(api.Token{AccessToken: "a", RefreshToken: "r"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	wrong, _ := Open(EncryptedFile, Options{Path: path, Passphrase: func(bool) (string, error) {
+		return "wrong", nil
+	}})
+	if _, err := wrong.Load(); !errors.Is(err, ErrPassphrase) {
+		t.Errorf("Expected ErrPassphrase, got %v", err)
+	}
+}
+
+func TestEncryptedFile_KeepsOtherAccounts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	passphrase := func(bool) (string, error) { return "secret", nil }
+	work, _ := Open(EncryptedFile, Options{Path: path, Account: "work", Passphrase: passphrase})
+	home, _ := Open(EncryptedFile, Options{Path: path, Account: "home", Passphrase: passphrase})
+
+	if err := work.Save(api.Token{AccessToken: "work"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := home.Save(api.Token{AccessToken: "home"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := home.Delete(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	loaded, err := work.Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if loaded.AccessToken != "work" {
+		t.Errorf("Expected the work account to survive, got %v", loaded)
+	}
+}
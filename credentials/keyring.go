@@ -0,0 +1,46 @@
+package credentials
+
+import (
+	"encoding/json"
+	"errors"
+
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/zalando/go-keyring"
+)
+
+const keyringService = "bootdev-cli"
+
+// keyringStore keeps the tokens in the freedesktop Secret Service on
+// Linux, or the Keychain on macOS.
+type keyringStore struct {
+	account string
+}
+
+func (s keyringStore) Load() (api.Token, error) {
+	secret, err := keyring.Get(keyringService, s.account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return api.Token{}, nil
+	}
+	if err != nil {
+		return api.Token{}, err
+	}
+	var token api.Token
+	err = json.Unmarshal([]byte(secret), &token)
+	return token, err
+}
+
+func (s keyringStore) Save(token api.Token) error {
+	secret, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	return keyring.Set(keyringService, s.account, string(secret))
+}
+
+func (s keyringStore) Delete() error {
+	err := keyring.Delete(keyringService, s.account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
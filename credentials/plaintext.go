@@ -0,0 +1,32 @@
+package credentials
+
+import (
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/profile"
+	"github.com/spf13/viper"
+)
+
+// plaintextStore keeps the tokens in the config file, where older
+// versions of the CLI put them, next to the rest of the account's
+// profile. Save only updates viper; writing the config file is left
+// to the caller.
+type plaintextStore struct {
+	account string
+}
+
+func (s plaintextStore) Load() (api.Token, error) {
+	return api.Token{
+		AccessToken:  viper.GetString(profile.KeyFor(s.account, "access_token")),
+		RefreshToken: viper.GetString(profile.KeyFor(s.account, "refresh_token")),
+	}, nil
+}
+
+func (s plaintextStore) Save(token api.Token) error {
+	viper.Set(profile.KeyFor(s.account, "access_token"), token.AccessToken)
+	viper.Set(profile.KeyFor(s.account, "refresh_token"), token.RefreshToken)
+	return nil
+}
+
+func (s plaintextStore) Delete() error {
+	return s.Save(api.Token{})
+}
@@ -0,0 +1,73 @@
+// Package credentials keeps the API tokens of the CLI, either in the
+// operating system's keyring, in a passphrase-encrypted file, or in
+// plaintext in the config file.
+package credentials
+
+import (
+	"errors"
+	"strings"
+
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/profile"
+)
+
+// The supported backends.
+const (
+	Plaintext     = "plaintext"
+	Keyring       = "keyring"
+	EncryptedFile = "file"
+)
+
+// DefaultBackend is used when no backend has been configured. It's
+// the plaintext config file, for compatibility with older versions.
+const DefaultBackend = Plaintext
+
+// Backends lists the supported backends.
+func Backends() []string {
+	return []string{Keyring, EncryptedFile, Plaintext}
+}
+
+// Store loads and saves the tokens of one account.
+type Store interface {
+	// Load returns the saved tokens, or empty ones if nothing is saved.
+	Load() (api.Token, error)
+	Save(api.Token) error
+	Delete() error
+}
+
+// Options configure the backends.
+type Options struct {
+	// Account tells apart several sets of tokens in the same backend.
+	// It's the name of a profile; the default profile is used if empty.
+	Account string
+	// Path of the encrypted credentials file.
+	Path string
+	// Passphrase is called when the encrypted file is opened. confirm is
+	// true when the file doesn't exist yet and a new passphrase is chosen.
+	Passphrase func(confirm bool) (string, error)
+}
+
+// ErrPassphrase is returned when the encrypted file can't be decrypted.
+// It's created before the locale is known, so unlike the other errors
+// it's in English and translated where it's shown.
+var ErrPassphrase = errors.New("wrong passphrase for the credentials file")
+
+// Open returns the store of the given backend.
+func Open(backend string, opts Options) (Store, error) {
+	if opts.Account == "" {
+		opts.Account = profile.Default
+	}
+	switch backend {
+	case Plaintext, "":
+		return plaintextStore{account: opts.Account}, nil
+	case Keyring:
+		return keyringStore{account: opts.Account}, nil
+	case EncryptedFile:
+		if opts.Path == "" || opts.Passphrase == nil {
+			return nil, errors.New(i18n.T("credentials.file_options"))
+		}
+		return &fileStore{path: opts.Path, account: opts.Account, passphrase: opts.Passphrase}, nil
+	}
+	return nil, errors.New(i18n.T("credentials.unknown_store", backend, strings.Join(Backends(), ", ")))
+}
@@ -0,0 +1,49 @@
+// Package fsutil has the file handling shared by the stores that keep
+// their data in files.
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// ValidName reports whether name, e.g. a UUID, can be used as a file
+// name without leaving its directory.
+func ValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// WriteFileAtomic writes data to a temporary file next to path and
+// renames it into place, so that a crash leaves either the old or the
+// new file, never half of one. The directory is created if needed.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := file.Name()
+	// a no-op once the file was renamed
+	defer os.Remove(tmp)
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
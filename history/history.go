@@ -0,0 +1,270 @@
+// Package history keeps a log of the lessons that were run and
+// submitted, one JSON line per run, so that past results can be looked
+// at again after the terminal scrolled past them.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/bootdotdev/bootdev/xdg"
+)
+
+// How a lesson was run.
+const (
+	// ModeRun ran the checks without a verdict.
+	ModeRun = "run"
+	// ModeSubmit submitted the results, the verdict is the API's.
+	ModeSubmit = "submit"
+	// ModeLocal checked a lesson from a file locally.
+	ModeLocal = "local"
+)
+
+// ErrNotFound is returned for a run that isn't in the history.
+var ErrNotFound = errors.New("run not found")
+
+// Run is a run of a lesson.
+type Run struct {
+	ID         int       `json:"id"`
+	LessonUUID string    `json:"lesson_uuid,omitempty"`
+	LessonFile string    `json:"lesson_file,omitempty"`
+	Time       time.Time `json:"time"`
+	Type       string    `json:"type"`
+	Mode       string    `json:"mode"`
+	// Passed is nil for runs without a verdict.
+	Passed *bool `json:"passed,omitempty"`
+	// FailedIndex is the request or command that failed, FailedTestIndex
+	// the test of it, and Message why.
+	FailedIndex     *int   `json:"failed_index,omitempty"`
+	FailedTestIndex *int   `json:"failed_test_index,omitempty"`
+	Message         string `json:"message,omitempty"`
+	// Lesson is the lesson as it was run, since it may change later.
+	Lesson json.RawMessage `json:"lesson"`
+	// Results are the results of the run, with the fields that are
+	// only rendered.
+	Results json.RawMessage `json:"results"`
+}
+
+// Log is a directory with the log of runs.
+type Log struct {
+	dir string
+}
+
+// New keeps the log in dir.
+func New(dir string) *Log {
+	return &Log{dir: dir}
+}
+
+// Open keeps the log in the history directory of the user's data dir.
+func Open() (*Log, error) {
+	dir, err := xdg.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return New(filepath.Join(dir, "history")), nil
+}
+
+// Dir is where the log is kept.
+func (l *Log) Dir() string {
+	return l.dir
+}
+
+func (l *Log) path() string {
+	return filepath.Join(l.dir, "runs.jsonl")
+}
+
+// rotatedPath is where the older runs are moved once the log is full.
+func (l *Log) rotatedPath() string {
+	return filepath.Join(l.dir, "runs.1.jsonl")
+}
+
+// maxLogSize is how big the log grows before it's rotated. The rotated
+// log replaces the one before it, so the history keeps between one and
+// two logs' worth of the most recent runs.
+var maxLogSize int64 = 8 << 20
+
+// Add appends a run to the log and returns it with its ID. Runs added
+// at the same time, e.g. from two terminals, take turns so that each
+// gets an ID of its own.
+func (l *Log) Add(run Run) (Run, error) {
+	if err := os.MkdirAll(l.dir, 0o700); err != nil {
+		return run, err
+	}
+	lock, err := os.OpenFile(filepath.Join(l.dir, "runs.lock"), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return run, err
+	}
+	// closing the file releases the lock
+	defer lock.Close()
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX); err != nil {
+		return run, err
+	}
+
+	if err := l.rotateIfFull(); err != nil {
+		return run, err
+	}
+	file, err := os.OpenFile(l.path(), os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o600)
+	if err != nil {
+		return run, err
+	}
+	defer file.Close()
+	id, err := lastID(file)
+	if err != nil {
+		return run, err
+	}
+	if id == 0 {
+		// the log was just rotated, the IDs go on where it left off
+		id, err = lastIDOf(l.rotatedPath())
+		if err != nil {
+			return run, err
+		}
+	}
+	run.ID = id + 1
+
+	data, err := json.Marshal(run)
+	if err != nil {
+		return run, err
+	}
+	// start on a line of its own, even after a line cut short by a crash
+	if info, err := file.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := file.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			data = append([]byte{'\n'}, data...)
+		}
+	}
+	_, err = file.Write(append(data, '\n'))
+	return run, err
+}
+
+func (l *Log) rotateIfFull() error {
+	info, err := os.Stat(l.path())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Size() < maxLogSize {
+		return nil
+	}
+	return os.Rename(l.path(), l.rotatedPath())
+}
+
+func lastIDOf(path string) (int, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	return lastID(file)
+}
+
+// lastID returns the ID of the last whole run in file, or 0 if there's
+// none. The file is read backwards from its end, so that adding a run
+// doesn't read the whole log.
+func lastID(file *os.File) (int, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	const chunkSize = 64 << 10
+	offset := info.Size()
+	var tail []byte
+	for offset > 0 {
+		n := min(chunkSize, offset)
+		offset -= n
+		chunk := make([]byte, n)
+		if _, err := file.ReadAt(chunk, offset); err != nil {
+			return 0, err
+		}
+		tail = append(chunk, tail...)
+		lines := bytes.Split(tail, []byte{'\n'})
+		// the first line is only known to be whole at the start of the file
+		first := 1
+		if offset == 0 {
+			first = 0
+		}
+		for i := len(lines) - 1; i >= first; i-- {
+			var run struct {
+				ID int `json:"id"`
+			}
+			// a line cut short by a crash doesn't parse
+			if len(lines[i]) > 0 && json.Unmarshal(lines[i], &run) == nil {
+				return run.ID, nil
+			}
+		}
+		tail = lines[0]
+	}
+	return 0, nil
+}
+
+// List returns every run, most recent first.
+func (l *Log) List() ([]Run, error) {
+	runs := []Run{}
+	for _, path := range []string{l.rotatedPath(), l.path()} {
+		logged, err := readRuns(path)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, logged...)
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].ID > runs[j].ID
+	})
+	return runs, nil
+}
+
+func readRuns(path string) ([]Run, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	runs := []Run{}
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		var run Run
+		// a line cut short by a crash is skipped, not the whole log
+		if len(line) > 0 && json.Unmarshal(line, &run) == nil {
+			runs = append(runs, run)
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return runs, nil
+}
+
+// Get returns a run by its ID.
+func (l *Log) Get(id int) (*Run, error) {
+	runs, err := l.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, run := range runs {
+		if run.ID == id {
+			return &run, nil
+		}
+	}
+	return nil, ErrNotFound
+}
@@ -0,0 +1,125 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLog(t *testing.T) {
+	l := New(t.TempDir())
+
+	if runs, err := l.List(); err != nil || len(runs) != 0 {
+		t.Fatalf("Expected an empty log, got %v, %v", runs, err)
+	}
+
+	passed := true
+	first, err := l.Add(Run{LessonUUID: "a", Time: time.Now(), Mode: ModeRun, Lesson: []byte(`{}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := l.Add(Run{LessonUUID: "b", Time: time.Now(), Mode: ModeSubmit, Passed: &passed, Lesson: []byte(`{}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID != 1 || second.ID != 2 {
+		t.Fatalf("Expected IDs 1 and 2, got %d and %d", first.ID, second.ID)
+	}
+
+	// a line cut short by a crash doesn't lose the rest
+	file, err := os.OpenFile(filepath.Join(l.Dir(), "runs.jsonl"), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"id": 3, "lesson_uu`)
+	file.Close()
+
+	third, err := l.Add(Run{LessonUUID: "c", Time: time.Now(), Mode: ModeRun, Lesson: []byte(`{}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := l.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third.ID != 3 || len(runs) != 3 || runs[0].ID != 3 || runs[1].ID != 2 || runs[2].ID != 1 {
+		t.Fatalf("Expected 3 runs, most recent first, got %+v", runs)
+	}
+
+	run, err := l.Get(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.LessonUUID != "b" || run.Passed == nil || !*run.Passed {
+		t.Errorf("Expected the second run, got %+v", run)
+	}
+	if _, err := l.Get(4); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestLogConcurrentAdds(t *testing.T) {
+	l := New(t.TempDir())
+	const n = 20
+	ids := make(chan int, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run, err := l.Add(Run{LessonUUID: "a", Time: time.Now(), Mode: ModeRun, Lesson: []byte(`{}`)})
+			if err != nil {
+				t.Error(err)
+			}
+			ids <- run.ID
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := map[int]bool{}
+	for id := range ids {
+		if seen[id] {
+			t.Errorf("ID %d was given out twice", id)
+		}
+		seen[id] = true
+	}
+	runs, err := l.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != n || runs[0].ID != n || runs[n-1].ID != 1 {
+		t.Fatalf("Expected runs 1 to %d, got %d runs from %d to %d", n, len(runs), runs[len(runs)-1].ID, runs[0].ID)
+	}
+}
+
+func TestLogRotation(t *testing.T) {
+	defer func(size int64) { maxLogSize = size }(maxLogSize)
+	maxLogSize = 1
+	l := New(t.TempDir())
+
+	for i := 1; i <= 3; i++ {
+		run, err := l.Add(Run{LessonUUID: "a", Time: time.Now(), Mode: ModeRun, Lesson: []byte(`{}`)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if run.ID != i {
+			t.Fatalf("Expected ID %d after rotating, got %d", i, run.ID)
+		}
+	}
+
+	// every run filled the log, so only the last two are left
+	runs, err := l.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 || runs[0].ID != 3 || runs[1].ID != 2 {
+		t.Fatalf("Expected runs 3 and 2, got %+v", runs)
+	}
+	if _, err := l.Get(1); err != ErrNotFound {
+		t.Errorf("Expected the first run to be rotated out, got %v", err)
+	}
+}
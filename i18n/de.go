@@ -0,0 +1,417 @@
+package i18n
+
+var german = Catalog{
+	// root
+	"root.short": "Die offizielle boot.dev CLI",
+	"root.long": `Die offizielle CLI für boot.dev. Dieses Programm ist als
+Begleiter (nicht als Ersatz) für die Website gedacht.`,
+	"root.flag.config":                "Konfigurationsdatei (Standard ist $HOME/.bootdev.yaml)",
+	"root.flag.profile":               "Profil, das statt des aktiven verwendet wird (auch BD_PROFILE)",
+	"root.read_config_error":          "Fehler beim Lesen der Konfigurationsdatei: %v",
+	"root.home_dir_error":             "Fehler beim Bestimmen des Home-Verzeichnisses: %v",
+	"root.read_configs_error":         "Fehler beim Lesen der Konfigurationsdateien: %v",
+	"root.write_default_config_error": "Fehler beim Schreiben der Standard-Konfigurationsdatei: %v",
+	"root.read_default_config_error":  "Fehler beim Lesen der Standard-Konfigurationsdatei: %v",
+	"root.update_info_error":          "Update-Informationen konnten nicht abgerufen werden. Bist du online?",
+	"root.update_check_offline":       "Updates konnten nicht geprüft werden, du scheinst offline zu sein. Es geht mit dieser Version weiter.",
+	"root.refresh_offline":            "Deine Anmeldung konnte nicht erneuert werden, Boot.dev scheint nicht erreichbar zu sein. Es geht offline weiter.",
+	"root.login_required":             "Für diesen Befehl musst du angemeldet sein.",
+	"root.login_prompt":               "Bitte führe zuerst 'bootdev login' aus.",
+	"config.write_error":              "Fehler beim Schreiben der Konfigurationsdatei: %v",
+
+	// run and submit
+	"run.short":                "Eine Lektion ausführen, ohne sie einzureichen",
+	"run.flag.submit":          "Kurzform, um einzureichen statt auszuführen",
+	"run.flag.offline":         "die zwischengespeicherte Lektion ausführen, ohne online zu gehen",
+	"run.flag.file":            "eine Lektion aus einer lokalen JSON- oder YAML-Datei ausführen und lokal prüfen statt einreichen",
+	"run.file_invalid":         "%s ist keine gültige Lektion:\n%v",
+	"run.flag.watch":           "die Lektion erneut ausführen, wenn sich Dateien in diesen Pfaden ändern (Standard: das aktuelle Verzeichnis)",
+	"run.flag.serve":           "deinen Server für die HTTP-Tests mit diesem Befehl starten, z. B. \"go run .\"",
+	"run.serve.exited":         "'%s' wurde beendet, bevor der Server bereit war: %v\n%s",
+	"run.serve.timeout":        "'%s' hat unter %s nicht innerhalb von %s einen Server gestartet\n%s",
+	"run.serve.port_in_use":    "%s ist bereits belegt, beende, was dort lauscht, damit --serve deinen Server starten kann",
+	"run.watch.error":          "Fehler: %v",
+	"submit.short":             "Eine Lektion einreichen",
+	"submit.flag.baseurl":      "Basis-URL für HTTP-Tests setzen und jeden Standardwert überschreiben",
+	"submit.flag.interactive":  "die Ergebnisse nach dem Durchlauf interaktiv erkunden",
+	"submit.flag.diff_context": "Anzahl unveränderter Zeilen, die um jeden Unterschied eines fehlgeschlagenen Tests angezeigt werden",
+	"submit.unsupported_type":  "nicht unterstützter Lektionstyp",
+	"submit.lesson_not_found":  "Lektion %s nicht gefunden, prüfe die UUID auf der Seite der Lektion",
+	"submit.not_cached":        "Lektion %s ist noch nicht zwischengespeichert, führe sie einmal online aus oder speichere ihren Kurs mit 'bootdev cache prefetch KURS'",
+
+	"api.unauthorized":       "deine Sitzung ist abgelaufen oder wurde widerrufen, bitte führe 'bootdev login' erneut aus",
+	"api.rate_limited":       "zu viele Anfragen, bitte warte einen Moment und versuche es erneut",
+	"api.rate_limited_retry": "zu viele Anfragen, bitte versuche es in %s erneut",
+	"api.server":             "Boot.dev hat gerade Probleme, bitte versuche es in ein paar Minuten erneut (%v)",
+	"api.network":            "%s ist nicht erreichbar, prüfe deine Internetverbindung (%v)",
+
+	// upgrade
+	"upgrade.short":          "Installiert die neueste Version der CLI.",
+	"upgrade.up_to_date":     "Die Boot.dev CLI ist bereits aktuell.",
+	"upgrade.install_error":  "Fehler beim Installieren der neuesten Version: %v",
+	"upgrade.command_output": "Ausgabe des Befehls: %s",
+	"upgrade.success":        "Erfolgreich auf %s aktualisiert!",
+
+	// login and logout
+	"login.short":               "Die CLI mit deinem Konto verbinden",
+	"login.welcome":             "Willkommen bei der boot.dev CLI!",
+	"login.navigate":            "Bitte öffne:\n%s",
+	"login.paste_code":          "Füge deinen Login-Code ein: ",
+	"login.browser_error":       "Browser konnte nicht geöffnet werden: %v",
+	"login.invalid_credentials": "ungültige Anmeldedaten erhalten",
+	"login.success":             "Erfolgreich angemeldet!",
+	"login.read_body_error":     "Anfrage konnte nicht gelesen werden",
+	"login.health_error":        "Antwort auf den Health-Check konnte nicht geschrieben werden: %v",
+	"login.server_error":        "Der lokale Login-Server konnte nicht gestartet werden (%v), füge den Code bitte unten ein",
+	"login.port_taken":          "Port %d ist bereits belegt, füge den Code unten ein oder wähle mit --port einen anderen Port",
+	"login.flag.port":           "lokaler Port, an den die Login-Seite den Code zurückschickt (Standard: ein beliebiger freier Port)",
+	"login.flag.with_token":     "statt über den Browser mit einem Refresh-Token von stdin anmelden, z. B. in CI",
+	"login.empty_token":         "kein Token auf stdin, übergib eines z. B. mit: bootdev login --with-token < token.txt",
+	"login.env_tokens":          "BD_ACCESS_TOKEN oder BD_REFRESH_TOKEN ist gesetzt und wird statt einer Anmeldung verwendet. Entferne die Variablen, um dich anzumelden",
+	"login.read_only_config":    "Anmeldung nicht möglich, die Konfigurationsdatei %s ist nicht beschreibbar. Übergib stattdessen ein Refresh-Token in BD_REFRESH_TOKEN",
+	"login.flag.device":         "durch Eingabe eines Codes auf einem anderen Gerät anmelden, z. B. über SSH oder in einem Container",
+	"login.device_expired":      "der Login-Code ist abgelaufen, bevor er eingegeben wurde, bitte versuche es erneut",
+	"login.device_denied":       "die Anmeldung wurde abgelehnt",
+	"login.device_canceled":     "Anmeldung abgebrochen",
+	"logout.short":              "Die CLI von deinem Konto trennen",
+	"logout.send_error":         "Fehler beim Senden der Logout-Anfrage: %v",
+	"logout.success":            "Erfolgreich abgemeldet.",
+
+	// auth
+	"auth.short":               "Die Anmeldedaten der CLI einsehen",
+	"auth.login_moved":         "'bootdev auth' allein ist veraltet, verwende 'bootdev login' zum Anmelden.",
+	"auth.status.short":        "Anzeigen, wo die CLI angemeldet ist und wann das Access-Token abläuft",
+	"auth.status.logged_out":   "Nicht angemeldet.",
+	"auth.status.logged_in":    "Angemeldet bei %s mit dem Profil %s",
+	"auth.status.expires_in":   "Das Access-Token läuft in %v ab (um %s).",
+	"auth.status.expired":      "Das Access-Token ist seit %v abgelaufen (um %s). Es wird bei der nächsten Anfrage erneuert.",
+	"auth.status.refresh_only": "Noch kein Access-Token, bei der nächsten Anfrage wird mit dem Refresh-Token eines geholt.",
+	"auth.status.opaque":       "Ablauf des Access-Tokens unbekannt; zuletzt vor %v erneuert, wird alle %v erneuert.",
+	"auth.status.store":        "Die Anmeldedaten liegen im Speicher %s.",
+	"auth.status.env":          "Tokens: aus BD_ACCESS_TOKEN/BD_REFRESH_TOKEN, nur im Speicher gehalten",
+	"auth.migrate.short":       "Die Tokens in einen anderen Anmeldedatenspeicher verschieben",
+	"auth.migrate.long": `Verschiebt die Tokens aus ihrem aktuellen Anmeldedatenspeicher, standardmäßig
+der Konfigurationsdatei im Klartext, in einen anderen:
+
+  keyring    der freedesktop Secret Service, oder der Schlüsselbund unter macOS
+  file       eine mit einer Passphrase verschlüsselte Datei (BD_CREDENTIALS_PASSPHRASE)
+  plaintext  die Konfigurationsdatei`,
+	"auth.migrate.flag.to": "Speicher, in den die Tokens verschoben werden, einer von %s",
+	"auth.migrate.already": "Die Anmeldedaten liegen bereits im Speicher %s.",
+	"auth.migrate.success": "Anmeldedaten vom Speicher %s in den Speicher %s verschoben.",
+
+	// credentials
+	"credentials.passphrase_prompt":   "Passphrase für die Anmeldedatendatei: ",
+	"credentials.passphrase_confirm":  "Passphrase wiederholen: ",
+	"credentials.passphrase_empty":    "die Passphrase darf nicht leer sein",
+	"credentials.passphrase_mismatch": "die Passphrasen stimmen nicht überein",
+	"credentials.passphrase_required": "die Anmeldedatendatei ist verschlüsselt; setze BD_CREDENTIALS_PASSPHRASE oder führe die CLI in einem Terminal aus",
+	"credentials.wrong_passphrase":    "falsche Passphrase für die Anmeldedatendatei",
+	"credentials.file_options":        "die verschlüsselte Anmeldedatendatei braucht einen Pfad und eine Passphrase",
+	"credentials.unknown_store":       "unbekannter Anmeldedatenspeicher '%s', verfügbare Speicher: %s",
+
+	// profile
+	"profile.short": "Die Konten verwalten, mit denen sich die CLI anmelden kann",
+	"profile.long": `Mit Profilen wechselst du zwischen Konten, z. B. einem persönlichen und
+einem Team-Konto. Jedes Profil hat eigene API-URL, Tokens und Farben. Das aktive
+Profil wird mit --profile, BD_PROFILE oder 'bootdev profile use' gewählt.`,
+	"profile.list.short":        "Die Profile auflisten",
+	"profile.use.short":         "Ein Profil zum aktiven machen",
+	"profile.use.success":       "Profil %s wird jetzt verwendet.",
+	"profile.add.short":         "Ein Profil hinzufügen",
+	"profile.add.success":       "Profil %s hinzugefügt.",
+	"profile.add.login":         "Führe 'bootdev login --profile %s' aus, um dich damit anzumelden.",
+	"profile.add.flag.api_url":  "URL der API, mit der das Profil spricht",
+	"profile.add.flag.base_url": "URL der Website, über die sich das Profil anmeldet",
+	"profile.remove.short":      "Ein Profil und seine Tokens entfernen",
+	"profile.remove.success":    "Profil %s entfernt.",
+	"profile.unknown":           "unbekanntes Profil '%s', verfügbare Profile: %s",
+	"profile.exists":            "das Profil '%s' existiert bereits",
+	"profile.invalid_name":      "ungültiger Profilname '%s': verwende Kleinbuchstaben, Ziffern, '-' oder '_'",
+	"profile.remove_default":    "das Standardprofil kann nicht entfernt werden",
+	"profile.remove_active":     "das Profil %s wird verwendet; wechsle zuerst zu einem anderen Profil",
+
+	// configure
+	"configure.short":                "Die Konfiguration der CLI ändern",
+	"configure.unset":                "%s zurückgesetzt",
+	"configure.set":                  "%s gesetzt!",
+	"configure.accessible_enabled":   "Barrierefreiheitsmodus aktiviert",
+	"configure.accessible_disabled":  "Barrierefreiheitsmodus deaktiviert",
+	"configure.help_error":           "Fehler beim Anzeigen der Hilfe: %v",
+	"configure.unknown_locale":       "unbekannte Sprache '%s', verfügbare Sprachen: %s",
+	"configure.locale_set":           "Sprache auf %s gesetzt!",
+	"configure.flag.accessible":      "Screenreader-freundliche Ausgabe: keine Animationen, Symbole oder reinen Farbhinweise (auch BD_ACCESSIBLE)",
+	"configure.flag.color":           "ANSI-Nummer oder Hex-Wert für %s, überschreibt das Theme (leer zum Zurücksetzen)",
+	"configure.flag.locale":          "Sprache der Meldungen der CLI, eine von %s (leer, um LANG zu folgen)",
+	"configure.flag.max_attempts":    "wie oft eine Anfrage an Boot.dev höchstens gesendet wird, bevor aufgegeben wird, 1 schaltet Wiederholungen ab",
+	"configure.max_attempts_set":     "Anfragen werden jetzt höchstens %d-mal gesendet.",
+	"configure.invalid_max_attempts": "die maximale Anzahl an Versuchen muss mindestens 1 sein",
+	"configure.theme.short":          "Ein Farbschema auswählen oder ansehen",
+	"configure.theme.long": `Wählt das Theme, mit dem Ergebnisse dargestellt werden. Ohne Namen werden
+die verfügbaren Themes aufgelistet. Themes können in der Konfigurationsdatei
+unter themes.<name> angepasst oder neu angelegt werden.`,
+	"configure.theme.set":          "Theme auf %s gesetzt!",
+	"configure.theme.flag.preview": "einen Beispiel-Ergebnisbaum anzeigen, statt das Theme zu speichern",
+
+	// checks
+	"checks.simulated_output":          "Simulierte Ausgabe für '%s'",
+	"checks.invalid_command":           "Ungültiger Befehl",
+	"checks.invalid_arguments":         "Ungültige Argumente",
+	"checks.command_not_allowed":       "Befehl nicht erlaubt",
+	"checks.no_base_url":               "keine Basis-URL angegeben",
+	"checks.create_request_error":      "Anfrage konnte nicht erstellt werden",
+	"checks.fetch_error":               "Abruf fehlgeschlagen",
+	"checks.read_body_error":           "Antwort konnte nicht gelesen werden",
+	"checks.parse_variables_error":     "Variablen konnten nicht ausgelesen werden: %v",
+	"checks.jq_value_count":            "ungültige Anzahl an Werten gefunden",
+	"checks.jq_value_not_found":        "Wert nicht gefunden",
+	"checks.eval.no_result":            "kein Ergebnis für diesen Schritt",
+	"checks.eval.status_code":          "Statuscode %d erwartet, %d erhalten",
+	"checks.eval.body_contains":        "Body sollte %q enthalten",
+	"checks.eval.header_missing":       "Header %s erwartet",
+	"checks.eval.header_value":         "Header %s sollte %q enthalten, ist aber %q",
+	"checks.eval.json_path":            "%s konnte nicht aus dem Body gelesen werden: %v",
+	"checks.eval.json_value":           "%s sollte %v sein, ist aber %v",
+	"checks.eval.exit_code":            "Exit-Code %d erwartet, %d erhalten",
+	"checks.eval.stdout_contains":      "stdout sollte %q enthalten",
+	"checks.eval.stdout_contains_none": "stdout sollte %q nicht enthalten",
+	"checks.eval.stdout_matches":       "stdout sollte auf %s passen",
+	"checks.eval.stdout_lines":         "mehr als %d Zeilen stdout erwartet, %d erhalten",
+
+	// lesson files
+	"lesson.unknown_format":          "%s: Lektionsdateien müssen .json, .yaml oder .yml sein",
+	"lesson.missing":                 "fehlt",
+	"lesson.unknown_type":            "unbekannter Typ %q, erwartet wird einer von %s",
+	"lesson.empty":                   "darf nicht leer sein",
+	"lesson.unknown_method":          "unbekannte Methode %q",
+	"lesson.path_slash":              "muss mit / beginnen",
+	"lesson.variable":                "braucht sowohl Name als auch Path",
+	"lesson.one_check":               "muss genau eine Sache prüfen, prüft %d",
+	"lesson.one_value":               "braucht genau einen von IntValue, StringValue und BoolValue, hat %d",
+	"lesson.gt_int":                  "gt funktioniert nur mit IntValue",
+	"lesson.unknown_operator":        "unbekannter Operator %q, erwartet wird einer von %s",
+	"lesson.undefined_variable":      "verwendet ${%s}, das keine vorherige Anfrage definiert",
+	"lesson.jq_path":                 "ungültiger jq-Pfad %q: %v",
+	"lesson.short":                   "Mit Lektionsdefinitionen arbeiten",
+	"lesson.lint.short":              "Lektionsdateien auf Fehler prüfen",
+	"lesson.lint.long":               "Lektionsdateien auf Fehler prüfen: unbekannte Felder, Tests, die nichts oder mehreres prüfen, undefinierte Variablen, ungültige jq-Pfade und reguläre Ausdrücke sowie Befehle, die nicht ausgeführt werden dürften.",
+	"lesson.lint.ok":                 "%s: ok",
+	"lesson.lint.problems":           "%s:",
+	"lesson.lint.failed":             "%d von %d Lektionsdateien haben Probleme",
+	"lesson.schema.short":            "Das JSON-Schema von Lektionsdateien ausgeben",
+	"lesson.schema.long":             "Das JSON-Schema von Lektionsdateien ausgeben, damit Editoren sie prüfen und vervollständigen können.",
+	"lesson.record.short":            "Eine HTTP-Lektion als Entwurf aus Anfragen an einen lokalen Server aufzeichnen",
+	"lesson.record.long":             "Anfragen von einem Browser oder curl an einen lokalen Server weiterleiten und aufzeichnen. Bei Strg+C werden sie als Entwurf einer Lektion geschrieben: Jede prüft ihren Statuscode und die Felder ihrer JSON-Antwort, und Werte, die an spätere Anfragen weitergegeben werden, werden zu Antwortvariablen. Andere Zugangsdaten in Authorization-Headern werden unkenntlich gemacht.",
+	"lesson.record.flag.base_url":    "der aufzuzeichnende Server, z. B. http://localhost:8080",
+	"lesson.record.flag.port":        "der lokale Port, an den Anfragen gesendet werden",
+	"lesson.record.flag.output":      "die Datei, in die der Entwurf geschrieben wird, statt stdout",
+	"lesson.record.invalid_base_url": "ungültige Basis-URL %q, erwartet wird etwa http://localhost:8080",
+	"lesson.record.listening":        "Aufzeichnung läuft, sende Anfragen an %s statt an %s. Strg+C zum Beenden.",
+	"lesson.record.exchange":         "  %s %s → %d",
+	"lesson.record.none":             "Es wurden keine Anfragen aufgezeichnet.",
+	"lesson.record.written":          "Entwurf mit %d Anfragen nach %s geschrieben.",
+
+	// version
+	"version.available":   "Eine neue Version der bootdev CLI ist verfügbar!",
+	"version.run_upgrade": "Bitte führe folgenden Befehl zum Aktualisieren aus:",
+	"version.fetch_error": "neueste Version konnte nicht abgerufen werden",
+
+	// render
+	"render.all_passed":        "Alle Tests bestanden! 🎉",
+	"render.return_to_browser": "Kehre zu deinem Browser zurück, um mit der nächsten Lektion weiterzumachen.",
+	"render.local_passed":      "Lokal geprüft, es wurde nichts eingereicht.",
+	"render.watch.changed":     "Geändert: %s",
+	"render.watch.watching":    "Beobachte %s auf Änderungen. Strg+C zum Beenden.",
+	"render.error":             "Fehler: %s",
+	"render.write_error":       "Fehler beim Schreiben des Outputs: %v",
+
+	"render.cmd.running":              "Ausführen: %s",
+	"render.cmd.exit_code":            "Exit-Code des Befehls: %d",
+	"render.cmd.stdout":               "Stdout des Befehls:",
+	"render.cmd.expect_exit_code":     "Erwarte Exit-Code %d",
+	"render.cmd.expect_lines_gt":      "Erwarte > %d Zeilen auf stdout",
+	"render.cmd.expect_matches":       "Erwarte, dass stdout auf '%s' passt",
+	"render.cmd.expect_contains_all":  "Erwarte, dass stdout alles hiervon enthält:",
+	"render.cmd.expect_contains_none": "Erwarte, dass stdout nichts hiervon enthält:",
+
+	"render.http.err":             "Fehler: %v",
+	"render.http.request_headers": "Request-Header:",
+	"render.http.status_code":     "Statuscode der Antwort: %v",
+	"render.http.body":            "Body der Antwort:",
+	"render.http.binary":          "Binäre %s-Datei",
+	"render.http.server_logs":     "Server-Logs:",
+	"render.http.expect_status":   "Erwarte Statuscode: %d",
+	"render.http.expect_body":     "Erwarte, dass der JSON-Body enthält: %s",
+	"render.http.expect_header":   "Erwarte, dass ein Header enthält: '%s: %v'",
+	"render.http.expect_json":     "Erwarte JSON bei %v %s %v",
+	"render.http.op_eq":           "gleich",
+	"render.http.op_gt":           "größer als",
+
+	"render.diff.title":               "Diff (erwartet vs. tatsächlich):",
+	"render.diff.expected":            "Erwartet",
+	"render.diff.actual":              "Tatsächlich",
+	"render.diff.header":              "Header",
+	"render.diff.expected_header":     "erwartet",
+	"render.diff.missing":             "fehlt",
+	"render.diff.found":               "gefunden",
+	"render.diff.contains":            "enthält '%s'",
+	"render.diff.not":                 "nicht '%s'",
+	"render.diff.status":              "Status %d",
+	"render.diff.exit_code":           "Exit-Code %d",
+	"render.diff.lines":               "%d Zeilen",
+	"render.diff.no_value":            "kein Wert bei %s",
+	"render.diff.invalid_json":        "Body der Antwort ist kein gültiges JSON",
+	"render.diff.collapsed":           "%d unveränderte Zeile(n)",
+	"render.diff.skipped":             "%d unveränderte Zeile(n) übersprungen.",
+	"render.diff.unchanged":           "Unverändert: %s",
+	"render.diff.matched":             "Gefunden: %s",
+	"render.diff.sentence_missing":    "Erwartet: %s. Tatsächlich: fehlt.",
+	"render.diff.sentence_unexpected": "Unerwartet: %s",
+	"render.diff.sentence_changed":    "Erwartet: %s. Tatsächlich: %s.",
+	"render.diff.sentence_header":     "Erwartet, dass Header %s '%s' enthält. Tatsächlich: %s.",
+	"render.diff.sentence_json":       "%s: erwartet %v, tatsächlich %v.",
+
+	"render.rundiff.request":         "Anfrage %d: %s %s",
+	"render.rundiff.command":         "Befehl %d: %s",
+	"render.rundiff.only_in":         "Nur in Ausführung %d.",
+	"render.rundiff.run":             "Ausführung %d",
+	"render.rundiff.error":           "Fehler",
+	"render.rundiff.status":          "Statuscode",
+	"render.rundiff.header":          "Header %s",
+	"render.rundiff.body":            "Body",
+	"render.rundiff.exit_code":       "Exit-Code",
+	"render.rundiff.stdout":          "Stdout",
+	"render.rundiff.line":            "Zeile",
+	"render.rundiff.none":            "(keiner)",
+	"render.rundiff.now_passes":      "Besteht jetzt: %s",
+	"render.rundiff.now_fails":       "Schlägt jetzt fehl: %s",
+	"render.rundiff.same":            "Keine Unterschiede.",
+	"render.rundiff.summary":         "%d Test(s) bestehen jetzt, %d schlagen jetzt fehl.",
+	"render.rundiff.sentence_change": "%s: %v in Ausführung %d, %v in Ausführung %d.",
+	"render.rundiff.sentence_only":   "Nur in Ausführung %d: %s",
+
+	"render.picker.placeholder":    "tippen zum Suchen",
+	"render.picker.no_matches":     "Keine Treffer.",
+	"render.picker.help":           "↑/↓: bewegen • Enter: auswählen • Esc: abbrechen",
+	"render.picker.number":         "Nummer (1-%d, Enter für %d, 0 zum Abbrechen): ",
+	"render.picker.invalid_number": "%q ist keine der Nummern.",
+	"render.outline.request":       "Anfrage %d: %s %s",
+	"render.outline.command":       "Befehl %d: %s",
+
+	"render.accessible.passed":          "BESTANDEN",
+	"render.accessible.failed":          "FEHLGESCHLAGEN",
+	"render.accessible.not_run":         "NICHT AUSGEFÜHRT",
+	"render.accessible.result_failed":   "Ergebnis: FEHLGESCHLAGEN. Fehler: %s",
+	"render.accessible.result_passed":   "Ergebnis: BESTANDEN. Alle Tests bestanden!",
+	"render.accessible.request":         "Anfrage %d von %d: %s %s",
+	"render.accessible.request_verdict": "Anfrage %d von %d%s",
+	"render.accessible.command":         "Befehl %d von %d: %s",
+	"render.accessible.command_verdict": "Befehl %d von %d%s",
+	"render.accessible.test":            "  Test %d von %d%s: %s",
+	"render.accessible.exit_code":       "Exit-Code des Befehls: %d",
+	"render.accessible.stdout":          "Stdout des Befehls:",
+
+	"render.explore.search":        "suchen",
+	"render.explore.copied":        "%s in die Zwischenablage kopiert",
+	"render.explore.no_matches":    "Keine Treffer für '%s'",
+	"render.explore.help":          "↑/↓ auswählen • Enter aufklappen • Bild↑/Bild↓ scrollen • / suchen • n/N nächster/vorheriger • c kopieren • q beenden",
+	"render.explore.accessible":    "Der interaktive Modus ist im Barrierefreiheitsmodus nicht verfügbar.",
+	"render.explore.curl_label":    "curl-Befehl",
+	"render.explore.command_label": "Befehl",
+
+	"render.device.instructions": "Öffne %s auf einem beliebigen Gerät und gib den Code ein:",
+	"render.device.code":         "Code: %s",
+	"render.device.direct_link":  "Oder öffne %s, um den Code nicht abtippen zu müssen.",
+	"render.device.waiting":      "Warte auf die Bestätigung der Anmeldung, der Code läuft in %v ab",
+	"render.device.cancel":       "Drücke q zum Abbrechen.",
+
+	"render.retrying": "%v, neuer Versuch in %s (Versuch %d)...",
+
+	"cache.short":                     "Die für die Offline-Nutzung zwischengespeicherten Lektionen verwalten",
+	"cache.list.short":                "Die zwischengespeicherten Lektionen auflisten",
+	"cache.list.empty":                "Es sind noch keine Lektionen zwischengespeichert.",
+	"cache.list.fetched":              "vor %s geladen",
+	"cache.clear.short":               "Alle zwischengespeicherten Lektionen entfernen",
+	"cache.clear.success":             "%d zwischengespeicherte Lektionen entfernt.",
+	"cache.prefetch.short":            "Alle Lektionen eines Kurses zwischenspeichern",
+	"cache.prefetch.long":             "Alle Lektionen eines Kurses zwischenspeichern, damit sie später mit 'bootdev run --offline' ausgeführt werden können.",
+	"cache.prefetch.course_not_found": "Kurs %s nicht gefunden, prüfe seine UUID oder seinen Slug",
+	"cache.prefetch.failed":           "%d von %d Lektionen konnten nicht zwischengespeichert werden",
+	"cache.prefetch.success":          "%d Lektionen von %s zwischengespeichert.",
+	"cache.invalid_uuid":              "ungültige Lektions-UUID %q",
+
+	"sync.short":       "Die offline zurückgestellten Abgaben senden",
+	"sync.long":        "Die Abgaben, die zurückgestellt wurden, weil Boot.dev nicht erreichbar war, der Reihe nach senden und ihre Ergebnisse anzeigen. Zurückgestellte Abgaben werden auch mit dem nächsten Befehl gesendet, der mit Boot.dev spricht.",
+	"sync.empty":       "Keine zurückgestellten Abgaben.",
+	"sync.queued":      "Boot.dev war nicht erreichbar, die Abgabe wurde daher zurückgestellt. Sie wird mit 'bootdev sync' oder dem nächsten Befehl gesendet, sobald du wieder online bist.",
+	"sync.sending":     "%d zurückgestellte Abgaben werden gesendet...",
+	"sync.submission":  "Lektion %s, zurückgestellt am %s:",
+	"sync.dropped":     "Die Abgabe wurde verworfen: %v",
+	"sync.unreadable":  "Eine zurückgestellte Abgabe konnte nicht gelesen werden und wurde nach %s verschoben: %v",
+	"sync.list_error":  "Die zurückgestellten Abgaben konnten nicht gelesen werden: %v",
+	"queue.invalid_id": "ungültige Abgabe-ID %q",
+
+	"history.short":          "Frühere Ausführungen und Einreichungen auflisten",
+	"history.long":           "Frühere Ausführungen und Einreichungen auflisten, die neuesten zuerst, optional nur die einer Lektion. Mit 'bootdev history show ID' werden die Ergebnisse einer davon erneut angezeigt.",
+	"history.show.short":     "Die Ergebnisse einer früheren Ausführung erneut anzeigen",
+	"history.show.header":    "Ausführung %d von %s am %s",
+	"history.empty":          "Noch keine Ausführungen.",
+	"history.invalid_id":     "ungültige ID %q, erwartet wird eine Zahl aus 'bootdev history'",
+	"history.not_found":      "Ausführung %d nicht gefunden, siehe 'bootdev history' für die vorhandenen",
+	"history.passed":         "bestanden",
+	"history.failed":         "fehlgeschlagen",
+	"history.failed_request": "fehlgeschlagen bei Anfrage %d, Test %d",
+	"history.failed_command": "fehlgeschlagen bei Befehl %d, Test %d",
+	"history.save_error":     "Die Ausführung konnte nicht zum Verlauf hinzugefügt werden: %v",
+
+	"results.missing_data": "die Lektion hat keine Daten für ihren Typ %s",
+
+	"diff.short":             "Zwei frühere Ausführungen vergleichen",
+	"diff.long":              "Zwei Ausführungen aus 'bootdev history' vergleichen. Anfragen und Befehle werden nach ihrer Position verglichen. Angezeigt wird, wo sich Statuscodes, Header, Bodys, Exit-Codes und Ausgaben unterscheiden und welche Tests in der einen Ausführung bestanden haben und in der anderen nicht.",
+	"diff.flag.diff_context": "Anzahl unveränderter Zeilen, die um jede geänderte Zeile angezeigt werden",
+	"diff.header":            "Vergleiche Ausführung %d (%s) mit Ausführung %d (%s)",
+	"diff.different_types":   "die Ausführungen %d und %d gehören zu verschiedenen Arten von Lektionen und können nicht verglichen werden",
+	"diff.different_lessons": "Hinweis: Die Ausführungen gehören zu verschiedenen Lektionen, %s und %s.",
+
+	"browse.courses.short":    "Die Kurse auflisten",
+	"browse.courses.empty":    "Keine Kurse gefunden.",
+	"browse.lessons.short":    "Die Lektionen eines Kurses auflisten",
+	"browse.lessons.long":     "Die Lektionen eines Kurses, angegeben durch seine UUID oder seinen Slug, der Reihe nach auflisten. Lektionen können über ihre UUID oder ihren Slug ausgeführt werden.",
+	"browse.lessons.empty":    "Der Kurs hat keine Lektionen.",
+	"browse.show.short":       "Eine Lektion anzeigen und was ihre Tests prüfen",
+	"browse.show.title":       "Titel:",
+	"browse.show.course":      "Kurs:",
+	"browse.show.type":        "Typ:",
+	"browse.show.status":      "Status:",
+	"browse.show.slug":        "Slug:",
+	"browse.show.uuid":        "UUID:",
+	"browse.column.title":     "TITEL",
+	"browse.column.slug":      "SLUG",
+	"browse.column.uuid":      "UUID",
+	"browse.column.type":      "TYP",
+	"browse.column.status":    "STATUS",
+	"browse.column.completed": "ABGESCHLOSSEN",
+	"browse.progress":         "%d von %d",
+	"browse.completed":        "abgeschlossen",
+	"browse.not_completed":    "nicht abgeschlossen",
+	"browse.type.http_tests":  "HTTP-Tests",
+	"browse.type.cli_command": "CLI-Befehl",
+	"browse.course_not_found": "Kurs %s nicht gefunden, prüfe seine UUID oder seinen Slug mit 'bootdev courses'",
+	"browse.lesson_not_found": "Lektion %s nicht gefunden, prüfe ihre UUID oder ihren Slug mit 'bootdev lessons COURSE'",
+	"browse.offline_uuid":     "offline können Lektionen nur über ihre UUID ausgeführt werden",
+	"browse.no_lesson":        "keine Lektion angegeben, übergib ihre UUID oder ihren Slug oder führe dies in einem Terminal aus, um eine auszuwählen",
+	"browse.pick.course":      "Wähle einen Kurs:",
+	"browse.pick.lesson":      "Wähle eine Lektion aus %s:",
+	"browse.pick.none":        "keine Lektion ausgewählt",
+	"browse.pick.no_lessons":  "%s hat keine Lektionen, die mit der CLI ausgeführt werden können",
+	"browse.pick.picked":      "%s (%s)",
+
+	"render.theme.unknown":         "unbekanntes Theme '%s', verfügbare Themes: %s",
+	"render.theme.unknown_border":  "Theme '%s' hat einen unbekannten Rahmen '%s'",
+	"render.theme.unknown_spinner": "Theme '%s' hat einen unbekannten Spinner '%s'",
+	"render.theme.preview_title":   "Theme: %s",
+	"render.theme.gray":            "grau",
+	"render.theme.red":             "rot",
+	"render.theme.green":           "grün",
+	"render.theme.accent":          "Akzent",
+}
@@ -0,0 +1,417 @@
+package i18n
+
+var english = Catalog{
+	// root
+	"root.short": "The official boot.dev CLI",
+	"root.long": `The official CLI for boot.dev. This program is meant
+to be a companion app (not a replacement) for the website.`,
+	"root.flag.config":                "config file (default is $HOME/.bootdev.yaml)",
+	"root.flag.profile":               "profile to use instead of the active one (also BD_PROFILE)",
+	"root.read_config_error":          "Error reading config file: %v",
+	"root.home_dir_error":             "Error determining the home directory: %v",
+	"root.read_configs_error":         "Error reading config files: %v",
+	"root.write_default_config_error": "Error writing the default config file: %v",
+	"root.read_default_config_error":  "Error reading the default config file: %v",
+	"root.update_info_error":          "Failed to fetch update info. Are you online?",
+	"root.update_check_offline":       "Couldn't check for updates, you seem to be offline. Carrying on with this version.",
+	"root.refresh_offline":            "Couldn't refresh your login, Boot.dev seems to be unreachable. Carrying on offline.",
+	"root.login_required":             "You must be logged in to use that command.",
+	"root.login_prompt":               "Please run 'bootdev login' first.",
+	"config.write_error":              "Error writing config: %v",
+
+	// run and submit
+	"run.short":                "Run a lesson without submitting",
+	"run.flag.submit":          "shortcut flag to submit instead of run",
+	"run.flag.offline":         "run the cached copy of the lesson without going online",
+	"run.flag.file":            "run a lesson from a local JSON or YAML file, checked locally instead of submitted",
+	"run.file_invalid":         "%s is not a valid lesson:\n%v",
+	"run.flag.watch":           "rerun the lesson when files in these paths change (default: the current directory)",
+	"run.flag.serve":           "start your server with this command for the HTTP tests, e.g. \"go run .\"",
+	"run.serve.exited":         "'%s' exited before the server was ready: %v\n%s",
+	"run.serve.timeout":        "'%s' didn't start a server at %s within %s\n%s",
+	"run.serve.port_in_use":    "%s is already in use, stop what's listening there so --serve can start your server",
+	"run.watch.error":          "Error: %v",
+	"submit.short":             "Submit a lesson",
+	"submit.flag.baseurl":      "set the base URL for HTTP tests, overriding any default",
+	"submit.flag.interactive":  "explore the results interactively once the run is done",
+	"submit.flag.diff_context": "number of unchanged lines to show around each difference of a failed test",
+	"submit.unsupported_type":  "unsupported lesson type",
+	"submit.lesson_not_found":  "lesson %s not found, check the UUID on the lesson's page",
+	"submit.not_cached":        "lesson %s isn't cached yet, run it once while online or cache its course with 'bootdev cache prefetch COURSE'",
+
+	"api.unauthorized":       "your session expired or was revoked, please run 'bootdev login' again",
+	"api.rate_limited":       "too many requests, please wait a moment and try again",
+	"api.rate_limited_retry": "too many requests, please try again in %s",
+	"api.server":             "Boot.dev is having trouble right now, please try again in a few minutes (%v)",
+	"api.network":            "couldn't reach %s, check your internet connection (%v)",
+
+	// upgrade
+	"upgrade.short":          "Installs the latest version of the CLI.",
+	"upgrade.up_to_date":     "Boot.dev CLI is already up to date.",
+	"upgrade.install_error":  "Error installing latest version: %v",
+	"upgrade.command_output": "Command output: %s",
+	"upgrade.success":        "Successfully upgraded to %s!",
+
+	// login and logout
+	"login.short":               "Authenticate the CLI with your account",
+	"login.welcome":             "Welcome to the boot.dev CLI!",
+	"login.navigate":            "Please navigate to:\n%s",
+	"login.paste_code":          "Paste your login code: ",
+	"login.browser_error":       "Failed to open browser: %v",
+	"login.invalid_credentials": "invalid credentials received",
+	"login.success":             "Logged in successfully!",
+	"login.read_body_error":     "Failed to read request body",
+	"login.health_error":        "Failed to write health check response: %v",
+	"login.server_error":        "Couldn't start the local login server (%v), paste the code below instead",
+	"login.port_taken":          "Port %d is already in use, paste the code below or pick another port with --port",
+	"login.flag.port":           "local port the login page sends the code back to (default: any free port)",
+	"login.flag.with_token":     "read a refresh token from stdin instead of logging in through the browser, e.g. in CI",
+	"login.empty_token":         "no token on stdin, pipe one in like: bootdev login --with-token < token.txt",
+	"login.env_tokens":          "BD_ACCESS_TOKEN or BD_REFRESH_TOKEN is set, those are used instead of a login. Unset them to log in",
+	"login.read_only_config":    "can't log in, the config file %s isn't writable. Pass a refresh token in BD_REFRESH_TOKEN instead",
+	"login.flag.device":         "log in by entering a code on another device, e.g. over SSH or in a container",
+	"login.device_expired":      "the login code expired before it was entered, please try again",
+	"login.device_denied":       "the login was denied",
+	"login.device_canceled":     "login canceled",
+	"logout.short":              "Disconnect the CLI from your account",
+	"logout.send_error":         "Error sending the logout request: %v",
+	"logout.success":            "Logged out successfully.",
+
+	// auth
+	"auth.short":               "Inspect the credentials of the CLI",
+	"auth.login_moved":         "'bootdev auth' on its own is deprecated, use 'bootdev login' to log in.",
+	"auth.status.short":        "Show who the CLI is logged in as and when the access token expires",
+	"auth.status.logged_out":   "Not logged in.",
+	"auth.status.logged_in":    "Logged in to %s with the %s profile",
+	"auth.status.expires_in":   "Access token expires in %v (at %s).",
+	"auth.status.expired":      "Access token expired %v ago (at %s). It will be refreshed on the next request.",
+	"auth.status.refresh_only": "No access token yet, one will be fetched with the refresh token on the next request.",
+	"auth.status.opaque":       "Access token expiry unknown; last refreshed %v ago, refreshed every %v.",
+	"auth.status.store":        "Credentials are kept in the %s store.",
+	"auth.status.env":          "Tokens: from BD_ACCESS_TOKEN/BD_REFRESH_TOKEN, kept in memory only",
+	"auth.migrate.short":       "Move the tokens to another credential store",
+	"auth.migrate.long": `Move the tokens out of their current credential store, by default the
+plaintext config file, into another one:
+
+  keyring    the freedesktop Secret Service, or the Keychain on macOS
+  file       a file encrypted with a passphrase (BD_CREDENTIALS_PASSPHRASE)
+  plaintext  the config file`,
+	"auth.migrate.flag.to": "store to move the tokens to, one of %s",
+	"auth.migrate.already": "Credentials are already kept in the %s store.",
+	"auth.migrate.success": "Moved credentials from the %s store to the %s store.",
+
+	// credentials
+	"credentials.passphrase_prompt":   "Passphrase for the credentials file: ",
+	"credentials.passphrase_confirm":  "Repeat the passphrase: ",
+	"credentials.passphrase_empty":    "the passphrase can't be empty",
+	"credentials.passphrase_mismatch": "the passphrases don't match",
+	"credentials.passphrase_required": "the credentials file is encrypted; set BD_CREDENTIALS_PASSPHRASE or run the CLI in a terminal",
+	"credentials.wrong_passphrase":    "wrong passphrase for the credentials file",
+	"credentials.file_options":        "the encrypted credentials file needs a path and a passphrase",
+	"credentials.unknown_store":       "unknown credential store '%s', available stores: %s",
+
+	// profile
+	"profile.short": "Manage the accounts the CLI can log in with",
+	"profile.long": `Profiles let you switch between accounts, e.g. a personal and a team
+account. Each profile has its own API URL, tokens and colors. The active
+profile is chosen with --profile, BD_PROFILE or 'bootdev profile use'.`,
+	"profile.list.short":        "List the profiles",
+	"profile.use.short":         "Make a profile the active one",
+	"profile.use.success":       "Now using the %s profile.",
+	"profile.add.short":         "Add a profile",
+	"profile.add.success":       "Added the %s profile.",
+	"profile.add.login":         "Run 'bootdev login --profile %s' to log in with it.",
+	"profile.add.flag.api_url":  "URL of the API the profile talks to",
+	"profile.add.flag.base_url": "URL of the website the profile logs in with",
+	"profile.remove.short":      "Remove a profile and its tokens",
+	"profile.remove.success":    "Removed the %s profile.",
+	"profile.unknown":           "unknown profile '%s', available profiles: %s",
+	"profile.exists":            "the profile '%s' already exists",
+	"profile.invalid_name":      "invalid profile name '%s': use lowercase letters, digits, '-' or '_'",
+	"profile.remove_default":    "the default profile can't be removed",
+	"profile.remove_active":     "the %s profile is in use; switch to another profile first",
+
+	// configure
+	"configure.short":                "Change configuration of the CLI",
+	"configure.unset":                "unset %s",
+	"configure.set":                  "set %s!",
+	"configure.accessible_enabled":   "accessibility mode enabled",
+	"configure.accessible_disabled":  "accessibility mode disabled",
+	"configure.help_error":           "Error showing help: %v",
+	"configure.unknown_locale":       "unknown locale '%s', available locales: %s",
+	"configure.locale_set":           "set locale to %s!",
+	"configure.flag.accessible":      "screen reader friendly output: no animation, glyphs or color-only cues (also BD_ACCESSIBLE)",
+	"configure.flag.color":           "ANSI number or hex string for %s, overrides the theme (empty to unset)",
+	"configure.flag.locale":          "language of the CLI's messages, one of %s (empty to follow LANG)",
+	"configure.flag.max_attempts":    "how often a request to Boot.dev is sent at most before giving up, 1 disables retries",
+	"configure.max_attempts_set":     "Requests are now sent at most %d times.",
+	"configure.invalid_max_attempts": "max attempts must be at least 1",
+	"configure.theme.short":          "Select or preview a color theme",
+	"configure.theme.long": `Select the theme used to draw results. Without a name, the available
+themes are listed. Themes can be customized, or new ones added, under
+themes.<name> in the config file.`,
+	"configure.theme.set":          "set theme to %s!",
+	"configure.theme.flag.preview": "render a sample result tree instead of saving the theme",
+
+	// checks
+	"checks.simulated_output":          "Simulated output for '%s'",
+	"checks.invalid_command":           "Invalid command",
+	"checks.invalid_arguments":         "Invalid arguments",
+	"checks.command_not_allowed":       "Command not allowed",
+	"checks.no_base_url":               "no base URL provided",
+	"checks.create_request_error":      "Failed to create request",
+	"checks.fetch_error":               "Failed to fetch",
+	"checks.read_body_error":           "Failed to read response body",
+	"checks.parse_variables_error":     "Failed to parse variables: %v",
+	"checks.jq_value_count":            "invalid number of values found",
+	"checks.jq_value_not_found":        "value not found",
+	"checks.eval.no_result":            "no result for this step",
+	"checks.eval.status_code":          "expected status code %d, got %d",
+	"checks.eval.body_contains":        "expected the body to contain %q",
+	"checks.eval.header_missing":       "expected a %s header",
+	"checks.eval.header_value":         "expected the %s header to contain %q, got %q",
+	"checks.eval.json_path":            "couldn't read %s from the body: %v",
+	"checks.eval.json_value":           "expected %s to be %v, got %v",
+	"checks.eval.exit_code":            "expected exit code %d, got %d",
+	"checks.eval.stdout_contains":      "expected stdout to contain %q",
+	"checks.eval.stdout_contains_none": "expected stdout not to contain %q",
+	"checks.eval.stdout_matches":       "expected stdout to match %s",
+	"checks.eval.stdout_lines":         "expected more than %d lines of stdout, got %d",
+
+	// lesson files
+	"lesson.unknown_format":          "%s: lesson files must be .json, .yaml or .yml",
+	"lesson.missing":                 "missing",
+	"lesson.unknown_type":            "unknown type %q, expected one of %s",
+	"lesson.empty":                   "must not be empty",
+	"lesson.unknown_method":          "unknown method %q",
+	"lesson.path_slash":              "must start with /",
+	"lesson.variable":                "needs both a Name and a Path",
+	"lesson.one_check":               "must check exactly one thing, checks %d",
+	"lesson.one_value":               "needs exactly one of IntValue, StringValue and BoolValue, has %d",
+	"lesson.gt_int":                  "gt only works with an IntValue",
+	"lesson.unknown_operator":        "unknown operator %q, expected one of %s",
+	"lesson.undefined_variable":      "uses ${%s}, which no earlier request defines",
+	"lesson.jq_path":                 "invalid jq path %q: %v",
+	"lesson.short":                   "Work on lesson definitions",
+	"lesson.lint.short":              "Check lesson files for mistakes",
+	"lesson.lint.long":               "Check lesson files for mistakes: unknown fields, tests that check zero or several things, undefined variables, invalid jq paths and regexes, and commands that wouldn't be allowed to run.",
+	"lesson.lint.ok":                 "%s: ok",
+	"lesson.lint.problems":           "%s:",
+	"lesson.lint.failed":             "%d of %d lesson files have problems",
+	"lesson.schema.short":            "Print the JSON Schema of lesson files",
+	"lesson.schema.long":             "Print the JSON Schema of lesson files, for editors to validate and complete them.",
+	"lesson.record.short":            "Record a draft HTTP lesson from requests to a local server",
+	"lesson.record.long":             "Proxy requests from a browser or curl to a local server and record them. On Ctrl+C, the requests are written as a draft lesson: each one checks its status code and the fields of its JSON response, and values passed on to later requests become response variables. Other credentials in Authorization headers are redacted.",
+	"lesson.record.flag.base_url":    "the server to record, e.g. http://localhost:8080",
+	"lesson.record.flag.port":        "the local port to send requests to",
+	"lesson.record.flag.output":      "the file to write the draft to, instead of stdout",
+	"lesson.record.invalid_base_url": "invalid base URL %q, expected something like http://localhost:8080",
+	"lesson.record.listening":        "Recording, send requests to %s instead of %s. Press Ctrl+C to stop.",
+	"lesson.record.exchange":         "  %s %s → %d",
+	"lesson.record.none":             "No requests were recorded.",
+	"lesson.record.written":          "Wrote a draft of %d requests to %s.",
+
+	// version
+	"version.available":   "A new version of the bootdev CLI is available!",
+	"version.run_upgrade": "Please run the following command to update:",
+	"version.fetch_error": "failed to fetch latest version",
+
+	// render
+	"render.all_passed":        "All tests passed! 🎉",
+	"render.return_to_browser": "Return to your browser to continue with the next lesson.",
+	"render.local_passed":      "Checked locally, nothing was submitted.",
+	"render.watch.changed":     "Changed: %s",
+	"render.watch.watching":    "Watching %s for changes. Press Ctrl+C to stop.",
+	"render.error":             "Error: %s",
+	"render.write_error":       "Error writing output: %v",
+
+	"render.cmd.running":              "Running: %s",
+	"render.cmd.exit_code":            "Command exit code: %d",
+	"render.cmd.stdout":               "Command stdout:",
+	"render.cmd.expect_exit_code":     "Expect exit code %d",
+	"render.cmd.expect_lines_gt":      "Expect > %d lines on stdout",
+	"render.cmd.expect_matches":       "Expect stdout to match '%s'",
+	"render.cmd.expect_contains_all":  "Expect stdout to contain all of:",
+	"render.cmd.expect_contains_none": "Expect stdout to contain none of:",
+
+	"render.http.err":             "Err: %v",
+	"render.http.request_headers": "Request Headers:",
+	"render.http.status_code":     "Response Status Code: %v",
+	"render.http.body":            "Response Body:",
+	"render.http.binary":          "Binary %s file",
+	"render.http.server_logs":     "Server Logs:",
+	"render.http.expect_status":   "Expecting status code: %d",
+	"render.http.expect_body":     "Expecting JSON body to contain: %s",
+	"render.http.expect_header":   "Expecting header to contain: '%s: %v'",
+	"render.http.expect_json":     "Expecting JSON at %v %s %v",
+	"render.http.op_eq":           "to be equal to",
+	"render.http.op_gt":           "to be greater than",
+
+	"render.diff.title":               "Diff (expected vs actual):",
+	"render.diff.expected":            "Expected",
+	"render.diff.actual":              "Actual",
+	"render.diff.header":              "Header",
+	"render.diff.expected_header":     "expected",
+	"render.diff.missing":             "missing",
+	"render.diff.found":               "found",
+	"render.diff.contains":            "contains '%s'",
+	"render.diff.not":                 "not '%s'",
+	"render.diff.status":              "status %d",
+	"render.diff.exit_code":           "exit code %d",
+	"render.diff.lines":               "%d lines",
+	"render.diff.no_value":            "no value at %s",
+	"render.diff.invalid_json":        "response body is not valid JSON",
+	"render.diff.collapsed":           "%d unchanged line(s)",
+	"render.diff.skipped":             "%d unchanged line(s) skipped.",
+	"render.diff.unchanged":           "Unchanged: %s",
+	"render.diff.matched":             "Matched: %s",
+	"render.diff.sentence_missing":    "Expected: %s. Actual: missing.",
+	"render.diff.sentence_unexpected": "Unexpected: %s",
+	"render.diff.sentence_changed":    "Expected: %s. Actual: %s.",
+	"render.diff.sentence_header":     "Expected header %s to contain '%s'. Actual: %s.",
+	"render.diff.sentence_json":       "%s: expected %v, actual %v.",
+
+	"render.rundiff.request":         "Request %d: %s %s",
+	"render.rundiff.command":         "Command %d: %s",
+	"render.rundiff.only_in":         "Only in run %d.",
+	"render.rundiff.run":             "Run %d",
+	"render.rundiff.error":           "Error",
+	"render.rundiff.status":          "Status code",
+	"render.rundiff.header":          "Header %s",
+	"render.rundiff.body":            "Body",
+	"render.rundiff.exit_code":       "Exit code",
+	"render.rundiff.stdout":          "Stdout",
+	"render.rundiff.line":            "Line",
+	"render.rundiff.none":            "(none)",
+	"render.rundiff.now_passes":      "Now passes: %s",
+	"render.rundiff.now_fails":       "Now fails: %s",
+	"render.rundiff.same":            "No differences.",
+	"render.rundiff.summary":         "%d test(s) now pass, %d now fail.",
+	"render.rundiff.sentence_change": "%s: %v in run %d, %v in run %d.",
+	"render.rundiff.sentence_only":   "Only in run %d: %s",
+
+	"render.picker.placeholder":    "type to search",
+	"render.picker.no_matches":     "No matches.",
+	"render.picker.help":           "↑/↓: move • enter: pick • esc: cancel",
+	"render.picker.number":         "Number (1-%d, Enter for %d, 0 to cancel): ",
+	"render.picker.invalid_number": "%q is not one of the numbers.",
+	"render.outline.request":       "Request %d: %s %s",
+	"render.outline.command":       "Command %d: %s",
+
+	"render.accessible.passed":          "PASSED",
+	"render.accessible.failed":          "FAILED",
+	"render.accessible.not_run":         "NOT RUN",
+	"render.accessible.result_failed":   "Result: FAILED. Error: %s",
+	"render.accessible.result_passed":   "Result: PASSED. All tests passed!",
+	"render.accessible.request":         "Request %d of %d: %s %s",
+	"render.accessible.request_verdict": "Request %d of %d%s",
+	"render.accessible.command":         "Command %d of %d: %s",
+	"render.accessible.command_verdict": "Command %d of %d%s",
+	"render.accessible.test":            "  Test %d of %d%s: %s",
+	"render.accessible.exit_code":       "Command exit code: %d",
+	"render.accessible.stdout":          "Command stdout:",
+
+	"render.explore.search":        "search",
+	"render.explore.copied":        "Copied %s to clipboard",
+	"render.explore.no_matches":    "No matches for '%s'",
+	"render.explore.help":          "↑/↓ select • enter expand • pgup/pgdn scroll • / search • n/N next/prev • c copy • q quit",
+	"render.explore.accessible":    "Interactive mode is not available in accessibility mode.",
+	"render.explore.curl_label":    "curl command",
+	"render.explore.command_label": "command",
+
+	"render.device.instructions": "Open %s on any device and enter the code:",
+	"render.device.code":         "Code: %s",
+	"render.device.direct_link":  "Or open %s to skip typing the code.",
+	"render.device.waiting":      "Waiting for the login to be approved, the code expires in %v",
+	"render.device.cancel":       "Press q to cancel.",
+
+	"render.retrying": "%v, retrying in %s (attempt %d)...",
+
+	"cache.short":                     "Manage the lessons cached for offline use",
+	"cache.list.short":                "List the cached lessons",
+	"cache.list.empty":                "No lessons are cached yet.",
+	"cache.list.fetched":              "fetched %s ago",
+	"cache.clear.short":               "Remove all cached lessons",
+	"cache.clear.success":             "Removed %d cached lessons.",
+	"cache.prefetch.short":            "Cache every lesson of a course",
+	"cache.prefetch.long":             "Cache every lesson of a course, so that they can be run with 'bootdev run --offline' later.",
+	"cache.prefetch.course_not_found": "course %s not found, check its UUID or slug",
+	"cache.prefetch.failed":           "%d of %d lessons couldn't be cached",
+	"cache.prefetch.success":          "Cached %d lessons of %s.",
+	"cache.invalid_uuid":              "invalid lesson UUID %q",
+
+	"sync.short":       "Send the submissions queued while offline",
+	"sync.long":        "Send the submissions that were queued because Boot.dev couldn't be reached, oldest first, and show their results. Queued submissions are also sent along with the next command that talks to Boot.dev.",
+	"sync.empty":       "No queued submissions.",
+	"sync.queued":      "Couldn't reach Boot.dev, so the submission was queued. It's sent with 'bootdev sync' or the next command once you're back online.",
+	"sync.sending":     "Sending %d queued submissions...",
+	"sync.submission":  "Lesson %s, queued %s:",
+	"sync.dropped":     "The submission was dropped: %v",
+	"sync.unreadable":  "Couldn't read a queued submission, so it was moved to %s: %v",
+	"sync.list_error":  "Couldn't read the queued submissions: %v",
+	"queue.invalid_id": "invalid submission ID %q",
+
+	"history.short":          "List past runs and submissions",
+	"history.long":           "List past runs and submissions, most recent first, optionally only those of one lesson. Use 'bootdev history show ID' to see the results of one again.",
+	"history.show.short":     "Show the results of a past run again",
+	"history.show.header":    "Run %d of %s at %s",
+	"history.empty":          "No runs yet.",
+	"history.invalid_id":     "invalid run ID %q, expected a number from 'bootdev history'",
+	"history.not_found":      "run %d not found, see 'bootdev history' for the runs there are",
+	"history.passed":         "passed",
+	"history.failed":         "failed",
+	"history.failed_request": "failed at request %d, test %d",
+	"history.failed_command": "failed at command %d, test %d",
+	"history.save_error":     "Couldn't add the run to the history: %v",
+
+	"results.missing_data": "the lesson has no data for its type %s",
+
+	"diff.short":             "Compare two past runs",
+	"diff.long":              "Compare two runs from 'bootdev history'. Requests and commands are compared by their position, showing where status codes, headers, bodies, exit codes and output differ, and which tests passed in one run but failed in the other.",
+	"diff.flag.diff_context": "number of unchanged lines to show around each changed line",
+	"diff.header":            "Comparing run %d (%s) with run %d (%s)",
+	"diff.different_types":   "runs %d and %d are of different kinds of lessons and can't be compared",
+	"diff.different_lessons": "Note: the runs are of different lessons, %s and %s.",
+
+	"browse.courses.short":    "List the courses",
+	"browse.courses.empty":    "No courses found.",
+	"browse.lessons.short":    "List the lessons of a course",
+	"browse.lessons.long":     "List the lessons of a course, given by its UUID or slug, in order. Lessons can be run by their UUID or slug.",
+	"browse.lessons.empty":    "The course has no lessons.",
+	"browse.show.short":       "Show a lesson and what its tests check",
+	"browse.show.title":       "Title:",
+	"browse.show.course":      "Course:",
+	"browse.show.type":        "Type:",
+	"browse.show.status":      "Status:",
+	"browse.show.slug":        "Slug:",
+	"browse.show.uuid":        "UUID:",
+	"browse.column.title":     "TITLE",
+	"browse.column.slug":      "SLUG",
+	"browse.column.uuid":      "UUID",
+	"browse.column.type":      "TYPE",
+	"browse.column.status":    "STATUS",
+	"browse.column.completed": "COMPLETED",
+	"browse.progress":         "%d of %d",
+	"browse.completed":        "completed",
+	"browse.not_completed":    "not completed",
+	"browse.type.http_tests":  "HTTP tests",
+	"browse.type.cli_command": "CLI command",
+	"browse.course_not_found": "course %s not found, check its UUID or slug with 'bootdev courses'",
+	"browse.lesson_not_found": "lesson %s not found, check its UUID or slug with 'bootdev lessons COURSE'",
+	"browse.offline_uuid":     "lessons can only be run offline by their UUID",
+	"browse.no_lesson":        "no lesson given, pass its UUID or slug, or run this in a terminal to pick one",
+	"browse.pick.course":      "Pick a course:",
+	"browse.pick.lesson":      "Pick a lesson of %s:",
+	"browse.pick.none":        "no lesson picked",
+	"browse.pick.no_lessons":  "%s has no lessons that can be run from the CLI",
+	"browse.pick.picked":      "%s (%s)",
+
+	"render.theme.unknown":         "unknown theme '%s', available themes: %s",
+	"render.theme.unknown_border":  "theme '%s' has an unknown border '%s'",
+	"render.theme.unknown_spinner": "theme '%s' has an unknown spinner '%s'",
+	"render.theme.preview_title":   "Theme: %s",
+	"render.theme.gray":            "gray",
+	"render.theme.red":             "red",
+	"render.theme.green":           "green",
+	"render.theme.accent":          "accent",
+}
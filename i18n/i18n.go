@@ -0,0 +1,100 @@
+// Package i18n holds the catalogs of user-facing messages and picks
+// the one matching the user's locale.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// DefaultLocale is used when no supported locale is configured.
+const DefaultLocale = "en"
+
+// Catalog maps message keys to fmt format strings.
+type Catalog map[string]string
+
+var catalogs = map[string]Catalog{
+	"en": english,
+	"de": german,
+}
+
+// The locale is detected from the environment right away so that
+// command help, which is built during package initialization, is
+// translated too. The config file can override it later on.
+var current = Detect("")
+
+// Locales returns the supported locales.
+func Locales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Detect returns the first supported locale out of the configured one,
+// LC_ALL, LC_MESSAGES and LANG, falling back to English.
+func Detect(configured string) string {
+	candidates := []string{
+		configured,
+		os.Getenv("LC_ALL"),
+		os.Getenv("LC_MESSAGES"),
+		os.Getenv("LANG"),
+	}
+	for _, candidate := range candidates {
+		if locale := normalize(candidate); locale != "" {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// normalize turns values like "de_DE.UTF-8" into a supported
+// locale, or returns an empty string.
+func normalize(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if i := strings.IndexAny(value, ".@"); i >= 0 {
+		value = value[:i]
+	}
+	if i := strings.IndexAny(value, "_-"); i >= 0 {
+		value = value[:i]
+	}
+	if value == "c" || value == "posix" {
+		return DefaultLocale
+	}
+	if _, ok := catalogs[value]; ok {
+		return value
+	}
+	return ""
+}
+
+// SetLocale switches the active catalog. Unsupported locales are ignored.
+func SetLocale(locale string) {
+	if locale = normalize(locale); locale != "" {
+		current = locale
+	}
+}
+
+// Locale returns the active locale.
+func Locale() string {
+	return current
+}
+
+// T looks up the message for key in the active catalog, falling back
+// to English and then to the key itself, and formats it with args.
+func T(key string, args ...any) string {
+	msg, ok := catalogs[current][key]
+	if !ok {
+		msg, ok = english[key]
+	}
+	if !ok {
+		msg = key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
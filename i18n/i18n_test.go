@@ -0,0 +1,79 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var verbRegex = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func TestCatalogsMatchEnglish(t *testing.T) {
+	for locale, catalog := range catalogs {
+		for key, msg := range english {
+			translated, ok := catalog[key]
+			if !ok {
+				t.Errorf("%s: missing key %q", locale, key)
+				continue
+			}
+			want := verbRegex.FindAllString(msg, -1)
+			got := verbRegex.FindAllString(translated, -1)
+			if !slices.Equal(want, got) {
+				t.Errorf("%s: %q has verbs %v, want %v", locale, key, got, want)
+			}
+		}
+		for key := range catalog {
+			if _, ok := english[key]; !ok {
+				t.Errorf("%s: key %q is not in the English catalog", locale, key)
+			}
+		}
+	}
+}
+
+func TestUsedKeysExist(t *testing.T) {
+	keyRegex := regexp.MustCompile(`i18n\.T\("([^"]+)"`)
+	err := filepath.WalkDir("..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && strings.HasPrefix(d.Name(), ".") && path != ".." {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range keyRegex.FindAllStringSubmatch(string(src), -1) {
+			if _, ok := english[match[1]]; !ok {
+				t.Errorf("%s: unknown message key %q", path, match[1])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "de_DE.UTF-8")
+	if got := Detect(""); got != "de" {
+		t.Errorf("Detect from LANG = %q, want de", got)
+	}
+	if got := Detect("en"); got != "en" {
+		t.Errorf("Detect with configured locale = %q, want en", got)
+	}
+	t.Setenv("LANG", "fr_FR.UTF-8")
+	if got := Detect(""); got != DefaultLocale {
+		t.Errorf("Detect with unsupported LANG = %q, want %s", got, DefaultLocale)
+	}
+}
@@ -0,0 +1,268 @@
+// Package lesson reads lesson definitions from files, so that authors
+// can run a lesson before it's published. A file holds an api.Lesson in
+// the same shape the API returns it, as JSON or YAML.
+package lesson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bootdotdev/bootdev/checks"
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/itchyny/gojq"
+	"gopkg.in/yaml.v3"
+)
+
+// Lesson types the CLI can run.
+const (
+	TypeHTTPTests  = "type_http_tests"
+	TypeCLICommand = "type_cli_command"
+)
+
+// Load reads a lesson from a .json, .yaml or .yml file. Unknown fields
+// are an error, so that a typo doesn't silently drop a test.
+func Load(path string) (*api.Lesson, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err = yamlToJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	case ".json":
+	default:
+		return nil, errors.New(i18n.T("lesson.unknown_format", path))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var lesson api.Lesson
+	if err := decoder.Decode(&lesson); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &lesson, nil
+}
+
+// yamlToJSON converts YAML to JSON, so that it's decoded with the same
+// field names as the API's JSON.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var parsed any
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+	return json.Marshal(parsed)
+}
+
+// Marshal encodes a lesson for a file. Fields that aren't set are left
+// out instead of written as null, to keep it readable.
+func Marshal(lesson *api.Lesson) ([]byte, error) {
+	data, err := json.Marshal(lesson)
+	if err != nil {
+		return nil, err
+	}
+	var parsed any
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+	data, err = json.MarshalIndent(withoutNulls(parsed), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func withoutNulls(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if field == nil {
+				delete(value, key)
+				continue
+			}
+			value[key] = withoutNulls(field)
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = withoutNulls(item)
+		}
+	}
+	return value
+}
+
+// Validate checks that a lesson can be run: each test checks exactly one
+// thing, jq paths and regexes compile, variables are defined before
+// they're used and commands are allowed. All problems are reported at once.
+func Validate(lesson *api.Lesson) error {
+	v := validator{}
+	switch lesson.Lesson.Type {
+	case TypeHTTPTests:
+		if lesson.Lesson.LessonDataHTTPTests == nil {
+			v.add("Lesson.LessonDataHTTPTests", i18n.T("lesson.missing"))
+			break
+		}
+		v.httpTests(lesson.Lesson.LessonDataHTTPTests)
+	case TypeCLICommand:
+		if lesson.Lesson.LessonDataCLICommand == nil {
+			v.add("Lesson.LessonDataCLICommand", i18n.T("lesson.missing"))
+			break
+		}
+		v.cliCommand(lesson.Lesson.LessonDataCLICommand)
+	case "":
+		v.add("Lesson.Type", i18n.T("lesson.missing"))
+	default:
+		v.add("Lesson.Type", i18n.T("lesson.unknown_type", lesson.Lesson.Type, TypeHTTPTests+", "+TypeCLICommand))
+	}
+	return v.err()
+}
+
+var methods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+// validator collects the problems of a lesson along with where they are.
+type validator struct {
+	problems []error
+}
+
+func (v *validator) add(path string, problem string) {
+	v.problems = append(v.problems, fmt.Errorf("%s: %s", path, problem))
+}
+
+func (v *validator) err() error {
+	return errors.Join(v.problems...)
+}
+
+func (v *validator) httpTests(data *api.LessonDataHTTPTests) {
+	if len(data.HttpTests.Requests) == 0 {
+		v.add("Lesson.LessonDataHTTPTests.HttpTests.Requests", i18n.T("lesson.empty"))
+	}
+	// variables are parsed from the responses, so each request can only
+	// use the ones defined by the requests before it
+	defined := map[string]bool{}
+	for i, request := range data.HttpTests.Requests {
+		path := fmt.Sprintf("Lesson.LessonDataHTTPTests.HttpTests.Requests[%d]", i)
+		method := strings.ToUpper(request.Request.Method)
+		if method == "" {
+			v.add(path+".Request.Method", i18n.T("lesson.missing"))
+		} else if !slices.Contains(methods, method) {
+			v.add(path+".Request.Method", i18n.T("lesson.unknown_method", request.Request.Method))
+		}
+		if !strings.HasPrefix(request.Request.Path, "/") {
+			v.add(path+".Request.Path", i18n.T("lesson.path_slash"))
+		}
+		keys := make([]string, 0, len(request.Request.Headers))
+		for key := range request.Request.Headers {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			for _, name := range checks.VariableNames(request.Request.Headers[key]) {
+				if !defined[name] {
+					v.add(fmt.Sprintf("%s.Request.Headers[%s]", path, key), i18n.T("lesson.undefined_variable", name))
+				}
+			}
+		}
+		for j, variable := range request.ResponseVariables {
+			variablePath := fmt.Sprintf("%s.ResponseVariables[%d]", path, j)
+			if variable.Name == "" || variable.Path == "" {
+				v.add(variablePath, i18n.T("lesson.variable"))
+				continue
+			}
+			v.jqPath(variablePath+".Path", variable.Path)
+			defined[variable.Name] = true
+		}
+		for j, test := range request.Tests {
+			v.httpTest(fmt.Sprintf("%s.Tests[%d]", path, j), test)
+		}
+	}
+}
+
+func (v *validator) httpTest(path string, test api.HTTPTest) {
+	set := count(test.StatusCode != nil, test.BodyContains != nil, test.HeadersContain != nil, test.JSONValue != nil)
+	if set != 1 {
+		v.add(path, i18n.T("lesson.one_check", set))
+		return
+	}
+	if test.HeadersContain != nil && test.HeadersContain.Key == "" {
+		v.add(path+".HeadersContain.Key", i18n.T("lesson.missing"))
+	}
+	if value := test.JSONValue; value != nil {
+		if value.Path == "" {
+			v.add(path+".JSONValue.Path", i18n.T("lesson.missing"))
+		} else {
+			v.jqPath(path+".JSONValue.Path", value.Path)
+		}
+		values := count(value.IntValue != nil, value.StringValue != nil, value.BoolValue != nil)
+		if values != 1 {
+			v.add(path+".JSONValue", i18n.T("lesson.one_value", values))
+		}
+		switch value.Operator {
+		case api.OpEquals, "":
+		case api.OpGreaterThan:
+			if value.IntValue == nil {
+				v.add(path+".JSONValue.Operator", i18n.T("lesson.gt_int"))
+			}
+		default:
+			v.add(path+".JSONValue.Operator", i18n.T("lesson.unknown_operator", value.Operator, api.OpEquals+", "+api.OpGreaterThan))
+		}
+	}
+}
+
+func (v *validator) cliCommand(data *api.LessonDataCLICommand) {
+	if len(data.CLICommandData.Commands) == 0 {
+		v.add("Lesson.LessonDataCLICommand.CLICommandData.Commands", i18n.T("lesson.empty"))
+	}
+	for i, command := range data.CLICommandData.Commands {
+		path := fmt.Sprintf("Lesson.LessonDataCLICommand.CLICommandData.Commands[%d]", i)
+		if strings.TrimSpace(command.Command) == "" {
+			v.add(path+".Command", i18n.T("lesson.missing"))
+		} else if violation := checks.CommandPolicy(command.Command); violation != "" {
+			v.add(path+".Command", violation)
+		}
+		for j, test := range command.Tests {
+			testPath := fmt.Sprintf("%s.Tests[%d]", path, j)
+			set := count(test.ExitCode != nil, test.StdoutContainsAll != nil, test.StdoutContainsNone != nil,
+				test.StdoutMatches != nil, test.StdoutLinesGt != nil)
+			if set != 1 {
+				v.add(testPath, i18n.T("lesson.one_check", set))
+				continue
+			}
+			if test.StdoutMatches != nil {
+				if _, err := regexp.Compile(*test.StdoutMatches); err != nil {
+					v.add(testPath+".StdoutMatches", err.Error())
+				}
+			}
+		}
+	}
+}
+
+// jqPath compiles the query the way the checks run it, which also
+// catches unknown functions and undefined variables.
+func (v *validator) jqPath(path string, query string) {
+	parsed, err := gojq.Parse(query)
+	if err == nil {
+		_, err = gojq.Compile(parsed)
+	}
+	if err != nil {
+		v.add(path, i18n.T("lesson.jq_path", query, err))
+	}
+}
+
+func count(conditions ...bool) int {
+	n := 0
+	for _, condition := range conditions {
+		if condition {
+			n++
+		}
+	}
+	return n
+}
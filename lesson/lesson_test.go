@@ -0,0 +1,138 @@
+package lesson
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bootdotdev/bootdev/i18n"
+)
+
+// The tests check messages in English, whatever the locale of the
+// environment they run in.
+func TestMain(m *testing.M) {
+	i18n.SetLocale("en")
+	os.Exit(m.Run())
+}
+
+func writeLesson(t *testing.T, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadYAML(t *testing.T) {
+	path := writeLesson(t, "lesson.yaml", `
+Lesson:
+  Type: type_cli_command
+  LessonDataCLICommand:
+    CLICommandData:
+      Commands:
+        - Command: echo hello $1
+          Tests:
+            - ExitCode: 0
+            - StdoutContainsAll: [hello]
+`)
+	lesson, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(lesson); err != nil {
+		t.Fatalf("Expected a valid lesson, got %v", err)
+	}
+	commands := lesson.Lesson.LessonDataCLICommand.CLICommandData.Commands
+	if len(commands) != 1 || commands[0].Command != "echo hello $1" || len(commands[0].Tests) != 2 {
+		t.Errorf("Expected the command and its tests, got %+v", commands)
+	}
+}
+
+func TestLoadUnknownField(t *testing.T) {
+	path := writeLesson(t, "lesson.json", `{"Lesson": {"Type": "type_cli_command", "Typo": true}}`)
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "Typo") {
+		t.Errorf("Expected an error about the unknown field, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	path := writeLesson(t, "lesson.json", `{"Lesson": {"Type": "type_http_tests", "LessonDataHTTPTests": {"HttpTests": {"Requests": [
+		{"Request": {"Method": "FETCH", "Path": "users", "Headers": {"Authorization": "Bearer ${token}"}},
+		 "ResponseVariables": [{"Name": "token", "Path": ".token["}],
+		 "Tests": [
+			{"StatusCode": 200, "BodyContains": "ok"},
+			{"JSONValue": {"Path": ".name", "Operator": "gt", "StringValue": "boots"}}
+		]}
+	]}}}}`)
+	lesson, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Validate(lesson)
+	if err == nil {
+		t.Fatal("Expected the lesson to be invalid")
+	}
+	for _, problem := range []string{
+		"Requests[0].Request.Method",
+		"Requests[0].Request.Path",
+		"Requests[0].Tests[0]",
+		"Requests[0].Tests[1].JSONValue.Operator",
+		"Requests[0].Request.Headers[Authorization]: uses ${token}",
+		"Requests[0].ResponseVariables[0].Path",
+	} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("Expected a problem with %s, got:\n%v", problem, err)
+		}
+	}
+}
+
+func TestValidateJQPaths(t *testing.T) {
+	path := writeLesson(t, "lesson.json", `{"Lesson": {"Type": "type_http_tests", "LessonDataHTTPTests": {"HttpTests": {"Requests": [
+		{"Request": {"Method": "GET", "Path": "/users"},
+		 "ResponseVariables": [{"Name": "id", "Path": ".[0].id"}],
+		 "Tests": [
+			{"JSONValue": {"Path": ".foo | nosuchfn", "Operator": "eq", "IntValue": 1}},
+			{"JSONValue": {"Path": "$x", "Operator": "eq", "IntValue": 1}},
+			{"JSONValue": {"Path": ".[0].name | length", "Operator": "gt", "IntValue": 1}}
+		]}
+	]}}}}`)
+	lesson, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Validate(lesson)
+	if err == nil {
+		t.Fatal("Expected the lesson to be invalid")
+	}
+	problems := strings.Split(err.Error(), "\n")
+	if len(problems) != 2 || !strings.Contains(problems[0], "Tests[0].JSONValue.Path") || !strings.Contains(problems[1], "Tests[1].JSONValue.Path") {
+		t.Errorf("Expected the unknown function and the undefined variable only, got:\n%v", err)
+	}
+}
+
+func TestValidateCommandPolicy(t *testing.T) {
+	path := writeLesson(t, "lesson.yml", `
+Lesson:
+  Type: type_cli_command
+  LessonDataCLICommand:
+    CLICommandData:
+      Commands:
+        - Command: rm -rf /
+        - Command: ls -R
+        - Command: ls -l $1
+`)
+	lesson, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Validate(lesson)
+	if err == nil {
+		t.Fatal("Expected the lesson to be invalid")
+	}
+	problems := strings.Split(err.Error(), "\n")
+	if len(problems) != 2 || !strings.Contains(problems[0], "Commands[0]") || !strings.Contains(problems[1], "Commands[1]") {
+		t.Errorf("Expected problems with the first two commands only, got:\n%v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package lesson
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	api "github.com/bootdotdev/bootdev/client"
+)
+
+// Exchange is a request sent through the recorder and the response it got.
+type Exchange struct {
+	Method          string
+	Path            string
+	Headers         http.Header
+	Body            []byte
+	StatusCode      int
+	ResponseHeaders http.Header
+	ResponseBody    []byte
+}
+
+// maxJSONValueTests is how many fields of a response are checked in a draft.
+const maxJSONValueTests = 5
+
+// redacted stands in for the credentials of an Authorization header that
+// isn't passed on from an earlier response, which aren't written to the
+// draft.
+const redacted = "<redacted>"
+
+// minVariableLength keeps short values like "1" or "ok" from being
+// mistaken for a value passed on to a later request.
+const minVariableLength = 8
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Draft turns recorded exchanges into a lesson to edit. Each request
+// checks its status code and the top-level fields of its JSON response.
+// A field whose value a later request sends in a header becomes a
+// response variable, and isn't checked since it likely changes per run.
+// Credentials that aren't passed on like that are redacted.
+func Draft(baseURL string, exchanges []Exchange) *api.Lesson {
+	data := &api.LessonDataHTTPTests{}
+	data.HttpTests.BaseURL = &baseURL
+	// the requests are of an anonymous struct type, which slices.Grow
+	// can allocate without spelling it out
+	requests := slices.Grow(data.HttpTests.Requests, len(exchanges))[:len(exchanges)]
+	data.HttpTests.Requests = requests
+
+	for i, exchange := range exchanges {
+		request := &requests[i]
+		request.Request.Method = exchange.Method
+		request.Request.Path = exchange.Path
+		request.Request.Headers = keptHeaders(exchange.Headers)
+		var body map[string]any
+		if json.Unmarshal(exchange.Body, &body) == nil {
+			request.Request.BodyJSON = body
+		}
+		statusCode := exchange.StatusCode
+		request.Tests = []api.HTTPTest{{StatusCode: &statusCode}}
+	}
+
+	used := map[string]bool{}
+	for i, exchange := range exchanges {
+		var response map[string]any
+		if json.Unmarshal(exchange.ResponseBody, &response) != nil {
+			continue
+		}
+		keys := make([]string, 0, len(response))
+		for key := range response {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		checked := 0
+		for _, key := range keys {
+			path := jqPath(key)
+			if value, ok := response[key].(string); ok && len(value) >= minVariableLength {
+				if name := passOn(data, i+1, value, key, used); name != "" {
+					requests[i].ResponseVariables = append(requests[i].ResponseVariables,
+						api.ResponseVariable{Name: name, Path: path})
+					continue
+				}
+			}
+			if checked == maxJSONValueTests {
+				continue
+			}
+			if test := jsonValueTest(path, response[key]); test != nil {
+				requests[i].Tests = append(requests[i].Tests, api.HTTPTest{JSONValue: test})
+				checked++
+			}
+		}
+	}
+
+	for _, request := range requests {
+		if auth, ok := request.Request.Headers["Authorization"]; ok && !strings.Contains(auth, "${") {
+			request.Request.Headers["Authorization"] = redactCredentials(auth)
+		}
+	}
+
+	lesson := &api.Lesson{}
+	lesson.Lesson.Type = TypeHTTPTests
+	lesson.Lesson.LessonDataHTTPTests = data
+	return lesson
+}
+
+// keptHeaders drops the headers that browsers and curl add on their
+// own, which a lesson has no reason to send.
+func keptHeaders(headers http.Header) map[string]string {
+	kept := map[string]string{}
+	for key, values := range headers {
+		key = http.CanonicalHeaderKey(key)
+		value := strings.Join(values, ",")
+		switch {
+		case key == "Accept" && value == "*/*":
+		case key == "Authorization", key == "Content-Type", key == "Accept", strings.HasPrefix(key, "X-"):
+			kept[key] = value
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
+
+// redactCredentials keeps only the scheme of an Authorization header,
+// like Bearer or Basic.
+func redactCredentials(auth string) string {
+	if scheme, _, ok := strings.Cut(auth, " "); ok {
+		return scheme + " " + redacted
+	}
+	return redacted
+}
+
+// passOn replaces value in the headers of the requests from index from
+// on with a variable and returns its name, or "" if they don't send it.
+func passOn(data *api.LessonDataHTTPTests, from int, value string, key string, used map[string]bool) string {
+	name := key
+	for n := 2; used[name]; n++ {
+		name = fmt.Sprintf("%s%d", key, n)
+	}
+	found := false
+	for _, request := range data.HttpTests.Requests[from:] {
+		for header, sent := range request.Request.Headers {
+			if strings.Contains(sent, value) {
+				request.Request.Headers[header] = strings.ReplaceAll(sent, value, "${"+name+"}")
+				found = true
+			}
+		}
+	}
+	if !found {
+		return ""
+	}
+	used[name] = true
+	return name
+}
+
+func jqPath(key string) string {
+	if identifier.MatchString(key) {
+		return "." + key
+	}
+	quoted, _ := json.Marshal(key)
+	return ".[" + string(quoted) + "]"
+}
+
+// jsonValueTest checks a field for its recorded value, if it's a value
+// a test can check.
+func jsonValueTest(path string, value any) *api.HTTPTestJSONValue {
+	test := &api.HTTPTestJSONValue{Path: path, Operator: api.OpEquals}
+	switch value := value.(type) {
+	case string:
+		test.StringValue = &value
+	case bool:
+		test.BoolValue = &value
+	case float64:
+		if value != float64(int(value)) {
+			return nil
+		}
+		n := int(value)
+		test.IntValue = &n
+	default:
+		return nil
+	}
+	return test
+}
@@ -0,0 +1,132 @@
+package lesson
+
+import (
+	"reflect"
+	"strings"
+
+	api "github.com/bootdotdev/bootdev/client"
+)
+
+// Schema returns a JSON Schema for lesson files. It's generated from
+// api.Lesson, so it can't drift from what the CLI decodes. Fields that
+// aren't needed are left out instead of set to null, as in the files
+// Load reads.
+func Schema() map[string]any {
+	g := schemaGenerator{defs: map[string]any{}}
+	schema := g.object(reflect.TypeOf(api.Lesson{}))
+	lesson := schema["properties"].(map[string]any)["Lesson"].(map[string]any)
+	lesson["required"] = []string{"Type"}
+	lessonType := lesson["properties"].(map[string]any)["Type"].(map[string]any)
+	lessonType["enum"] = []string{TypeHTTPTests, TypeCLICommand}
+	schema["required"] = []string{"Lesson"}
+
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "Boot.dev lesson"
+	schema["$defs"] = g.defs
+	return schema
+}
+
+// oneCheck is for tests that check exactly one thing.
+func oneCheck(schema map[string]any) {
+	schema["minProperties"] = 1
+	schema["maxProperties"] = 1
+}
+
+// refinements add what the Go types can't say to their schemas.
+var refinements = map[reflect.Type]func(schema map[string]any){
+	reflect.TypeOf(api.HTTPTest{}):           oneCheck,
+	reflect.TypeOf(api.CLICommandTestCase{}): oneCheck,
+	reflect.TypeOf(api.OperatorType("")): func(schema map[string]any) {
+		schema["enum"] = []api.OperatorType{api.OpEquals, api.OpGreaterThan}
+	},
+	reflect.TypeOf(api.HTTPTestJSONValue{}): func(schema map[string]any) {
+		schema["required"] = []string{"Path"}
+		schema["oneOf"] = []map[string]any{
+			{"required": []string{"IntValue"}},
+			{"required": []string{"StringValue"}},
+			{"required": []string{"BoolValue"}},
+		}
+	},
+	reflect.TypeOf(api.HTTPTestHeader{}): func(schema map[string]any) {
+		schema["required"] = []string{"Key"}
+	},
+	reflect.TypeOf(api.ResponseVariable{}): func(schema map[string]any) {
+		schema["required"] = []string{"Name", "Path"}
+	},
+}
+
+// schemaGenerator keeps the named structs in $defs, anonymous ones are
+// inlined.
+type schemaGenerator struct {
+	defs map[string]any
+}
+
+func (g *schemaGenerator) schema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct && t.Name() != "" {
+		if _, ok := g.defs[t.Name()]; !ok {
+			// placeholder first, in case the type refers to itself
+			g.defs[t.Name()] = map[string]any{}
+			g.defs[t.Name()] = g.object(t)
+		}
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	}
+
+	var schema map[string]any
+	switch t.Kind() {
+	case reflect.Struct:
+		return g.object(t)
+	case reflect.Slice, reflect.Array:
+		schema = map[string]any{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		schema = map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.String:
+		schema = map[string]any{"type": "string"}
+	case reflect.Bool:
+		schema = map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema = map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		schema = map[string]any{"type": "number"}
+	default:
+		// interface{} takes any JSON value
+		schema = map[string]any{}
+	}
+	if refine, ok := refinements[t]; ok {
+		refine(schema)
+	}
+	return schema
+}
+
+func (g *schemaGenerator) object(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		properties[name] = g.schema(field.Type)
+	}
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if refine, ok := refinements[t]; ok {
+		refine(schema)
+	}
+	return schema
+}
@@ -0,0 +1,44 @@
+package lesson
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	data, err := json.Marshal(Schema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema struct {
+		Properties struct {
+			Lesson struct {
+				Properties map[string]struct {
+					Enum []string `json:"enum"`
+					Ref  string   `json:"$ref"`
+				} `json:"properties"`
+			}
+		} `json:"properties"`
+		Defs map[string]struct {
+			MaxProperties int            `json:"maxProperties"`
+			Properties    map[string]any `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	properties := schema.Properties.Lesson.Properties
+	if types := strings.Join(properties["Type"].Enum, ","); types != TypeHTTPTests+","+TypeCLICommand {
+		t.Errorf("Expected the lesson types as enum, got %q", types)
+	}
+	if ref := properties["LessonDataHTTPTests"].Ref; ref != "#/$defs/LessonDataHTTPTests" {
+		t.Errorf("Expected a reference to the HTTP tests, got %q", ref)
+	}
+	for _, name := range []string{"HTTPTest", "CLICommandTestCase"} {
+		if def := schema.Defs[name]; def.MaxProperties != 1 || len(def.Properties) < 4 {
+			t.Errorf("Expected %s to allow exactly one of its checks, got %+v", name, def)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Package profile lets one config file hold several accounts. The
+// default profile lives at the top level of the config, like it did
+// before profiles existed, and every other one under profiles.<name>.
+package profile
+
+import (
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/spf13/viper"
+)
+
+// Default is the profile kept at the top level of the config.
+const Default = "default"
+
+var active = Default
+
+// SetActive selects the profile that Key resolves against. Like config
+// keys, profile names are case-insensitive.
+func SetActive(name string) {
+	if name == "" {
+		name = Default
+	}
+	active = strings.ToLower(name)
+}
+
+// Active returns the name of the selected profile.
+func Active() string {
+	return active
+}
+
+// Key returns the config key under which the active profile keeps key.
+func Key(key string) string {
+	return KeyFor(active, key)
+}
+
+// KeyFor returns the config key under which the named profile keeps key.
+func KeyFor(name string, key string) string {
+	if name == Default {
+		return key
+	}
+	return "profiles." + name + "." + key
+}
+
+// Dir returns the directory under base that keeps the files of the
+// active profile, like its cached lessons. The default profile keeps
+// them in base itself, like before profiles existed.
+func Dir(base string) string {
+	if active == Default {
+		return base
+	}
+	return filepath.Join(base, "profiles", active)
+}
+
+// Names lists the default profile along with those in the config file.
+func Names() []string {
+	names := []string{}
+	for name := range viper.GetStringMap("profiles") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{Default}, names...)
+}
+
+// Exists reports whether the named profile exists.
+func Exists(name string) bool {
+	return name == Default || viper.IsSet("profiles."+name)
+}
+
+// ValidateName checks that name can be used for a new profile.
+func ValidateName(name string) error {
+	if name == "" || name != strings.ToLower(name) || strings.ContainsAny(name, ". \t\n") {
+		return errors.New(i18n.T("profile.invalid_name", name))
+	}
+	if Exists(name) {
+		return errors.New(i18n.T("profile.exists", name))
+	}
+	return nil
+}
+
+// Remove deletes the named profile from the config file. Neither the
+// default nor the active profile can be removed.
+func Remove(name string) error {
+	if name == Default {
+		return errors.New(i18n.T("profile.remove_default"))
+	}
+	if name == active {
+		return errors.New(i18n.T("profile.remove_active", name))
+	}
+	if !Exists(name) {
+		return errors.New(i18n.T("profile.unknown", name, strings.Join(Names(), ", ")))
+	}
+	// viper can't unset keys, so the config is rebuilt without the
+	// profile, from the file alone: the settings of the global viper also
+	// hold defaults and the environment, which don't belong in the file
+	file := viper.New()
+	file.SetConfigFile(viper.ConfigFileUsed())
+	if err := file.ReadInConfig(); err != nil {
+		return err
+	}
+	settings := file.AllSettings()
+	if profiles, ok := settings["profiles"].(map[string]any); ok {
+		delete(profiles, name)
+	}
+	rewritten := viper.New()
+	rewritten.SetConfigFile(viper.ConfigFileUsed())
+	if err := rewritten.MergeConfigMap(settings); err != nil {
+		return err
+	}
+	if err := rewritten.WriteConfig(); err != nil {
+		return err
+	}
+	return viper.ReadInConfig()
+}
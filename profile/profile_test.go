@@ -0,0 +1,50 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bootdev.yaml")
+	config := "api_url: https://api.boot.dev\nprofiles:\n  work:\n    access_token: work-token\n  old:\n    access_token: old-token\n"
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	viper.SetDefault("frontend_url", "https://boot.dev")
+	viper.SetEnvPrefix("bd")
+	viper.AutomaticEnv()
+	t.Setenv("BD_ACCESS_TOKEN", "env-token")
+	t.Cleanup(viper.Reset)
+
+	if err := Remove("old"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	written := string(data)
+	if strings.Contains(written, "old") {
+		t.Errorf("Expected the profile removed, got:\n%s", written)
+	}
+	if !strings.Contains(written, "work-token") || !strings.Contains(written, "api.boot.dev") {
+		t.Errorf("Expected the rest of the file kept, got:\n%s", written)
+	}
+	// neither defaults nor the environment belong in the file
+	if strings.Contains(written, "frontend_url") || strings.Contains(written, "env-token") {
+		t.Errorf("Expected only what was in the file, got:\n%s", written)
+	}
+	if Exists("old") {
+		t.Error("Expected the profile gone from the loaded config too")
+	}
+}
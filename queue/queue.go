@@ -0,0 +1,145 @@
+// Package queue keeps submissions that couldn't reach the API on disk,
+// one JSON file per submission, until they can be sent.
+package queue
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bootdotdev/bootdev/fsutil"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/xdg"
+)
+
+// Submission is a submission waiting to be sent.
+type Submission struct {
+	// ID is also the idempotency key the submission is sent with, so
+	// it's only counted once even if an earlier attempt got through.
+	ID         string    `json:"id"`
+	LessonUUID string    `json:"lesson_uuid"`
+	QueuedAt   time.Time `json:"queued_at"`
+	// Payload is the body of the submit request, exactly as it's sent.
+	Payload json.RawMessage `json:"payload"`
+	// Lesson and Results are what's needed to render the verdict.
+	Lesson  json.RawMessage `json:"lesson"`
+	Results json.RawMessage `json:"results"`
+}
+
+// Unreadable is a queued submission that couldn't be read. It's moved
+// out of the queue to Path, so that it doesn't hold up the others.
+type Unreadable struct {
+	Path string
+	Err  error
+}
+
+// Queue is a directory of queued submissions.
+type Queue struct {
+	dir string
+}
+
+// New keeps submissions in dir.
+func New(dir string) *Queue {
+	return &Queue{dir: dir}
+}
+
+// Open keeps submissions in the queue directory of the user's data dir.
+func Open() (*Queue, error) {
+	dir, err := xdg.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return New(filepath.Join(dir, "queue")), nil
+}
+
+// Dir is where the submissions are kept.
+func (q *Queue) Dir() string {
+	return q.dir
+}
+
+func (q *Queue) path(id string) (string, error) {
+	if !fsutil.ValidName(id) {
+		return "", errors.New(i18n.T("queue.invalid_id", id))
+	}
+	return filepath.Join(q.dir, id+".json"), nil
+}
+
+// Add queues a submission.
+func (q *Queue) Add(submission Submission) error {
+	path, err := q.path(submission.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(submission)
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(path, data, 0o600)
+}
+
+// List returns the queued submissions, oldest first, and the ones that
+// couldn't be read, which are moved to the corrupt directory of the
+// queue to be looked at.
+func (q *Queue) List() ([]Submission, []Unreadable, error) {
+	files, err := os.ReadDir(q.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	submissions := []Submission{}
+	unreadable := []Unreadable{}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(q.dir, file.Name())
+		data, err := os.ReadFile(path)
+		var submission Submission
+		if err == nil {
+			err = json.Unmarshal(data, &submission)
+		}
+		if err != nil {
+			unreadable = append(unreadable, Unreadable{Path: q.quarantine(path), Err: err})
+			continue
+		}
+		submissions = append(submissions, submission)
+	}
+	sort.Slice(submissions, func(i, j int) bool {
+		return submissions[i].QueuedAt.Before(submissions[j].QueuedAt)
+	})
+	return submissions, unreadable, nil
+}
+
+// quarantine moves the file at path to the corrupt directory and returns
+// where it is now, which is still path if it couldn't be moved.
+func (q *Queue) quarantine(path string) string {
+	dir := filepath.Join(q.dir, "corrupt")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return path
+	}
+	moved := filepath.Join(dir, filepath.Base(path))
+	if err := os.Rename(path, moved); err != nil {
+		return path
+	}
+	return moved
+}
+
+// Remove takes a submission off the queue.
+func (q *Queue) Remove(id string) error {
+	path, err := q.path(id)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,81 @@
+package queue
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestQueue(t *testing.T) {
+	q := New(t.TempDir())
+
+	if submissions, _, err := q.List(); err != nil || len(submissions) != 0 {
+		t.Fatalf("Expected an empty queue, got %v, %v", submissions, err)
+	}
+
+	now := time.Now()
+	for _, submission := range []Submission{
+		{ID: "second", LessonUUID: "b", QueuedAt: now, Payload: []byte(`{"b":2}`)},
+		{ID: "first", LessonUUID: "a", QueuedAt: now.Add(-time.Minute), Payload: []byte(`{"a":1}`)},
+	} {
+		if err := q.Add(submission); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	submissions, _, err := q.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(submissions) != 2 || submissions[0].ID != "first" || submissions[1].ID != "second" {
+		t.Fatalf("Expected 2 submissions, oldest first, got %+v", submissions)
+	}
+	if string(submissions[0].Payload) != `{"a":1}` {
+		t.Errorf("Expected the payload as it was queued, got %s", submissions[0].Payload)
+	}
+
+	if err := q.Remove("first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Remove("first"); err != nil {
+		t.Errorf("Expected removing twice to be fine, got %v", err)
+	}
+	if submissions, _, _ := q.List(); len(submissions) != 1 || submissions[0].ID != "second" {
+		t.Errorf("Expected only the second submission left, got %+v", submissions)
+	}
+
+	if err := q.Add(Submission{ID: "../escape"}); err == nil {
+		t.Error("Expected an error for an ID that's a path")
+	}
+}
+
+func TestQueueMovesUnreadableAside(t *testing.T) {
+	dir := t.TempDir()
+	q := New(dir)
+	if err := q.Add(Submission{ID: "fine", LessonUUID: "a", QueuedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"id": "bro`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	submissions, unreadable, err := q.List()
+	if err != nil {
+		t.Fatalf("Expected one broken file not to fail the list, got %v", err)
+	}
+	if len(submissions) != 1 || submissions[0].ID != "fine" {
+		t.Errorf("Expected the readable submission, got %+v", submissions)
+	}
+	moved := filepath.Join(dir, "corrupt", "broken.json")
+	if len(unreadable) != 1 || unreadable[0].Path != moved || unreadable[0].Err == nil {
+		t.Fatalf("Expected the broken file reported at %s, got %+v", moved, unreadable)
+	}
+	if _, err := os.Stat(moved); err != nil {
+		t.Errorf("Expected the broken file moved aside: %v", err)
+	}
+
+	if _, unreadable, _ := q.List(); len(unreadable) != 0 {
+		t.Errorf("Expected the broken file reported once, got %+v", unreadable)
+	}
+}
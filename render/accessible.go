@@ -0,0 +1,157 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bootdotdev/bootdev/checks"
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/spf13/viper"
+)
+
+// Accessible reports whether output should be tailored to screen readers:
+// no animation, no box drawing and no meaning conveyed by color alone.
+// It's enabled with the accessible config key or the BD_ACCESSIBLE env var.
+func Accessible() bool {
+	return viper.GetBool("accessible")
+}
+
+func accessibleStyles() styles {
+	t := builtinThemes["monochrome"]
+	t.Border = "hidden"
+	t.Symbols.Pass = i18n.T("render.accessible.passed")
+	t.Symbols.Fail = i18n.T("render.accessible.failed")
+	t.Symbols.Unknown = i18n.T("render.accessible.not_run")
+	st := newStyles(t)
+	st.accessible = true
+	return st
+}
+
+// verdict spells out the state of a request, command or test.
+func (st styles) verdict(isSubmit bool, passed *bool) string {
+	if !isSubmit {
+		return ""
+	}
+	if passed == nil {
+		return ", " + st.unknown
+	}
+	if *passed {
+		return ", " + st.pass
+	}
+	return ", " + st.fail
+}
+
+// announcer writes progress one complete line at a time so screen
+// readers can read each line as it arrives.
+type announcer struct {
+	isSubmit bool
+	local    bool
+}
+
+func (a announcer) line(key string, args ...any) {
+	fmt.Println(i18n.T(key, args...))
+}
+
+func (a announcer) block(text string) {
+	text = strings.TrimRight(text, "\n")
+	if text != "" {
+		fmt.Println(text)
+	}
+}
+
+func (a announcer) summary(errorMessage *string) {
+	if errorMessage != nil {
+		a.line("render.accessible.result_failed", *errorMessage)
+	} else if a.isSubmit {
+		a.line("render.accessible.result_passed")
+		if a.local {
+			a.line("render.local_passed")
+		} else {
+			a.line("render.return_to_browser")
+		}
+	}
+}
+
+func accessibleHTTPRenderer(
+	st styles,
+	data api.LessonDataHTTPTests,
+	results []checks.HttpTestResult,
+	failure *api.HTTPTestValidationError,
+	isSubmit bool,
+	local bool,
+	diffContext int,
+) {
+	a := announcer{isSubmit: isSubmit, local: local}
+	failedReq, failedTest := -1, -1
+	if failure != nil && failure.FailedRequestIndex != nil && failure.FailedTestIndex != nil {
+		failedReq, failedTest = *failure.FailedRequestIndex, *failure.FailedTestIndex
+	}
+	requests := data.HttpTests.Requests
+	for i, req := range requests {
+		a.line("render.accessible.request", i+1, len(requests), req.Request.Method, req.Request.Path)
+		for j, test := range req.Tests {
+			passed := resultState(isSubmit, failure != nil, failedReq, i, failedTest, j)
+			a.line("render.accessible.test", j+1, len(req.Tests), st.verdict(isSubmit, passed), prettyPrintHTTPTest(test))
+		}
+		passed := resultState(isSubmit, failure != nil, failedReq, i, -1, -1)
+		a.line("render.accessible.request_verdict", i+1, len(requests), st.verdict(isSubmit, passed))
+		if i < len(results) && (!isSubmit || failedReq == i) {
+			a.block(printHTTPResult(results[i]))
+		}
+		if failedReq == i {
+			a.block(st.httpFailureDiff(data, results, failure, diffContext))
+		}
+		if i < len(results) && (failedReq == i || results[i].Err != "") {
+			a.block(st.serverLogs(results[i]))
+		}
+	}
+	if failure != nil {
+		a.summary(failure.ErrorMessage)
+	} else {
+		a.summary(nil)
+	}
+}
+
+func accessibleCommandRenderer(
+	st styles,
+	data api.LessonDataCLICommand,
+	results []api.CLICommandResult,
+	failure *api.StructuredErrCLICommand,
+	isSubmit bool,
+	local bool,
+	diffContext int,
+) {
+	a := announcer{isSubmit: isSubmit, local: local}
+	failedCmd, failedTest := -1, -1
+	if failure != nil {
+		failedCmd, failedTest = failure.FailedCommandIndex, failure.FailedTestIndex
+	}
+	commands := data.CLICommandData.Commands
+	for i, cmd := range commands {
+		command := cmd.Command
+		if i < len(results) {
+			command = results[i].FinalCommand
+		}
+		a.line("render.accessible.command", i+1, len(commands), command)
+		for j, test := range cmd.Tests {
+			passed := resultState(isSubmit, failure != nil, failedCmd, i, failedTest, j)
+			a.line("render.accessible.test", j+1, len(cmd.Tests), st.verdict(isSubmit, passed), prettyPrintCmd(test))
+		}
+		passed := resultState(isSubmit, failure != nil, failedCmd, i, -1, -1)
+		a.line("render.accessible.command_verdict", i+1, len(commands), st.verdict(isSubmit, passed))
+		if i < len(results) && (!isSubmit || failedCmd == i) {
+			a.line("render.accessible.exit_code", results[i].ExitCode)
+			a.line("render.accessible.stdout")
+			a.block(checks.TranslateOutput(results[i].Stdout))
+		}
+		if failedCmd == i {
+			a.block(st.cmdFailureDiff(data, results, failure, diffContext))
+		}
+	}
+	if failure != nil {
+		a.summary(&failure.ErrorMessage)
+	} else {
+		a.summary(nil)
+	}
+}
@@ -7,12 +7,12 @@ import (
 	"sync"
 	"time"
 
+	"github.com/bootdotdev/bootdev/checks"
 	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/termenv"
-	"github.com/spf13/viper"
 )
 
 type doneCmdMsg struct {
@@ -27,12 +27,14 @@ type resolveCmdMsg struct {
 	index   int
 	passed  *bool
 	results *api.CLICommandResult
+	diff    string
 }
 
 type cmdModel struct {
 	command  string
 	passed   *bool
 	results  *api.CLICommandResult
+	diff     string
 	finished bool
 	tests    []testModel
 }
@@ -40,27 +42,29 @@ type cmdModel struct {
 type cmdRootModel struct {
 	cmds      []cmdModel
 	spinner   spinner.Model
+	st        styles
 	failure   *api.StructuredErrCLICommand
 	isSubmit  bool
+	local     bool
 	success   bool
 	finalized bool
 	clear     bool
 }
 
-func initialModelCmd(isSubmit bool) cmdRootModel {
+func initialModelCmd(isSubmit bool, local bool) cmdRootModel {
+	st := currentStyles()
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = st.spinner
 	return cmdRootModel{
 		spinner:  s,
+		st:       st,
 		isSubmit: isSubmit,
+		local:    local,
 		cmds:     []cmdModel{},
 	}
 }
 
 func (m cmdRootModel) Init() tea.Cmd {
-	green = lipgloss.NewStyle().Foreground(lipgloss.Color(viper.GetString("color.green")))
-	red = lipgloss.NewStyle().Foreground(lipgloss.Color(viper.GetString("color.red")))
-	gray = lipgloss.NewStyle().Foreground(lipgloss.Color(viper.GetString("color.gray")))
 	return m.spinner.Tick
 }
 
@@ -75,13 +79,14 @@ func (m cmdRootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 
 	case startCmdMsg:
-		m.cmds = append(m.cmds, cmdModel{command: fmt.Sprintf("Running: %s", msg.cmd), tests: []testModel{}})
+		m.cmds = append(m.cmds, cmdModel{command: i18n.T("render.cmd.running", msg.cmd), tests: []testModel{}})
 		return m, nil
 
 	case resolveCmdMsg:
 		m.cmds[msg.index].passed = msg.passed
 		m.cmds[msg.index].finished = true
 		m.cmds[msg.index].results = msg.results
+		m.cmds[msg.index].diff = msg.diff
 		return m, nil
 
 	case startTestMsg:
@@ -110,46 +115,51 @@ func (m cmdRootModel) View() string {
 	s := m.spinner.View()
 	var str string
 	for _, cmd := range m.cmds {
-		str += renderTestHeader(cmd.command, m.spinner, cmd.finished, m.isSubmit, cmd.passed)
-		str += renderTests(cmd.tests, s)
+		str += m.st.renderTestHeader(cmd.command, m.spinner, cmd.finished, m.isSubmit, cmd.passed)
+		str += m.st.renderTests(cmd.tests, s)
 		if cmd.results != nil && m.finalized {
 			// render the results
-			str += fmt.Sprintf("\n > Command exit code: %d\n", cmd.results.ExitCode)
-			str += " > Command stdout:\n\n"
-			sliced := strings.Split(cmd.results.Stdout, "\n")
+			str += "\n > " + i18n.T("render.cmd.exit_code", cmd.results.ExitCode) + "\n"
+			str += " > " + i18n.T("render.cmd.stdout") + "\n\n"
+			sliced := strings.Split(checks.TranslateOutput(cmd.results.Stdout), "\n")
 			for _, s := range sliced {
-				str += gray.Render(s) + "\n"
+				str += m.st.gray.Render(s) + "\n"
 			}
+			str += cmd.diff
 		}
 	}
 	if m.failure != nil {
-		str += red.Render("\n\nError: "+m.failure.ErrorMessage) + "\n\n"
+		str += m.st.red.Render("\n\n"+i18n.T("render.error", m.failure.ErrorMessage)) + "\n\n"
 	} else if m.success {
-		str += "\n\n" + green.Render("All tests passed! 🎉") + "\n\n"
-		str += green.Render("Return to your browser to continue with the next lesson.") + "\n\n"
+		str += "\n\n" + m.st.green.Render(i18n.T("render.all_passed")) + "\n\n"
+		if m.local {
+			str += m.st.green.Render(i18n.T("render.local_passed")) + "\n\n"
+		} else {
+			str += m.st.green.Render(i18n.T("render.return_to_browser")) + "\n\n"
+		}
 	}
 	return str
 }
 
 func prettyPrintCmd(test api.CLICommandTestCase) string {
 	if test.ExitCode != nil {
-		return fmt.Sprintf("Expect exit code %d", *test.ExitCode)
+		return i18n.T("render.cmd.expect_exit_code", *test.ExitCode)
 	}
 	if test.StdoutLinesGt != nil {
-		return fmt.Sprintf("Expect > %d lines on stdout", *test.StdoutLinesGt)
+		return i18n.T("render.cmd.expect_lines_gt", *test.StdoutLinesGt)
 	}
 	if test.StdoutMatches != nil {
-		return fmt.Sprintf("Expect stdout to match '%s'", *test.StdoutMatches)
+		return i18n.T("render.cmd.expect_matches", *test.StdoutMatches)
 	}
 	if test.StdoutContainsAll != nil {
-		str := "Expect stdout to contain all of:"
+		str := i18n.T("render.cmd.expect_contains_all")
 		for _, thing := range test.StdoutContainsAll {
 			str += fmt.Sprintf("\n      - '%s'", thing)
 		}
 		return str
 	}
 	if test.StdoutContainsNone != nil {
-		str := "Expect stdout to contain none of:"
+		str := i18n.T("render.cmd.expect_contains_none")
 		for _, thing := range test.StdoutContainsNone {
 			str += fmt.Sprintf("\n      - '%s'", thing)
 		}
@@ -166,15 +176,27 @@ func CommandRun(
 	data api.LessonDataCLICommand,
 	results []api.CLICommandResult,
 ) {
-	commandRenderer(data, results, nil, false)
+	commandRenderer(data, results, nil, false, false, DefaultDiffContext)
 }
 
 func CommandSubmission(
 	data api.LessonDataCLICommand,
 	results []api.CLICommandResult,
 	failure *api.StructuredErrCLICommand,
+	diffContext int,
 ) {
-	commandRenderer(data, results, failure, true)
+	commandRenderer(data, results, failure, true, false, diffContext)
+}
+
+// CommandLocal renders results that were checked locally, by a lesson
+// that isn't published and so can't be submitted.
+func CommandLocal(
+	data api.LessonDataCLICommand,
+	results []api.CLICommandResult,
+	failure *api.StructuredErrCLICommand,
+	diffContext int,
+) {
+	commandRenderer(data, results, failure, true, true, diffContext)
 }
 
 func commandRenderer(
@@ -182,21 +204,29 @@ func commandRenderer(
 	results []api.CLICommandResult,
 	failure *api.StructuredErrCLICommand,
 	isSubmit bool,
+	local bool,
+	diffContext int,
 ) {
+	if Accessible() {
+		accessibleCommandRenderer(currentStyles(), data, results, failure, isSubmit, local, diffContext)
+		return
+	}
 	var wg sync.WaitGroup
 	ch := make(chan tea.Msg, 1)
-	p := tea.NewProgram(initialModelCmd(isSubmit), tea.WithoutSignalHandler())
+	model := initialModelCmd(isSubmit, local)
+	st := model.st
+	p := newProgram(model, tea.WithoutSignalHandler())
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		if model, err := p.Run(); err != nil {
+		if model, err := runProgram(p); err != nil {
 			fmt.Fprintln(os.Stderr, err)
 		} else if r, ok := model.(cmdRootModel); ok {
 			r.clear = false
 			r.finalized = true
 			output := termenv.NewOutput(os.Stdout)
 			if _, err := output.WriteString(r.View()); err != nil {
-				fmt.Fprintf(os.Stderr, "Fehler beim Schreiben des Outputs: %v\n", err)
+				fmt.Fprintln(os.Stderr, i18n.T("render.write_error", err))
 				return
 			}
 		}
@@ -250,7 +280,12 @@ func commandRenderer(
 				if passed {
 					ch <- resolveCmdMsg{index: i, passed: pointerToBool(passed)}
 				} else {
-					ch <- resolveCmdMsg{index: i, passed: pointerToBool(passed), results: &results[i]}
+					ch <- resolveCmdMsg{
+						index:   i,
+						passed:  pointerToBool(passed),
+						results: &results[i],
+						diff:    st.cmdFailureDiff(data, results, failure, diffContext),
+					}
 				}
 			}
 		}
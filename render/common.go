@@ -8,11 +8,6 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-var green lipgloss.Style
-var red lipgloss.Style
-var gray lipgloss.Style
-var borderBox = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
-
 type testModel struct {
 	text     string
 	passed   *bool
@@ -28,23 +23,24 @@ type resolveTestMsg struct {
 	passed *bool
 }
 
-func renderTestHeader(header string, spinner spinner.Model, isFinished bool, isSubmit bool, passed *bool) string {
-	cmdStr := renderTest(header, spinner.View(), isFinished, &isSubmit, passed)
-	box := borderBox.Render(fmt.Sprintf(" %s ", cmdStr))
+func (st styles) renderTestHeader(header string, spinner spinner.Model, isFinished bool, isSubmit bool, passed *bool) string {
+	cmdStr := st.renderTest(header, spinner.View(), isFinished, &isSubmit, passed)
+	box := st.borderBox.Render(fmt.Sprintf(" %s ", cmdStr))
 	sliced := strings.Split(box, "\n")
-	sliced[2] = strings.Replace(sliced[2], "─", "┬", 1)
+	bottom := len(sliced) - 1
+	sliced[bottom] = strings.Replace(sliced[bottom], st.borderBox.GetBorderStyle().Bottom, st.connector, 1)
 	return strings.Join(sliced, "\n") + "\n"
 }
 
-func renderTests(tests []testModel, spinner string) string {
+func (st styles) renderTests(tests []testModel, spinner string) string {
 	var str string
 	for _, test := range tests {
-		testStr := renderTest(test.text, spinner, test.finished, nil, test.passed)
+		testStr := st.renderTest(test.text, spinner, test.finished, nil, test.passed)
 		testStr = fmt.Sprintf("  %s", testStr)
 
-		edges := " ├─"
+		edges := " " + st.branch
 		for i := 0; i < lipgloss.Height(testStr)-1; i++ {
-			edges += "\n │ "
+			edges += "\n " + st.trunk + " "
 		}
 		str += lipgloss.JoinHorizontal(lipgloss.Top, edges, testStr)
 		str += "\n"
@@ -53,18 +49,18 @@ func renderTests(tests []testModel, spinner string) string {
 	return str
 }
 
-func renderTest(text string, spinner string, isFinished bool, isSubmit *bool, passed *bool) string {
+func (st styles) renderTest(text string, spinner string, isFinished bool, isSubmit *bool, passed *bool) string {
 	testStr := ""
 	if !isFinished {
 		testStr += fmt.Sprintf("%s %s", spinner, text)
 	} else if isSubmit != nil && !*isSubmit {
 		testStr += text
 	} else if passed == nil {
-		testStr += gray.Render(fmt.Sprintf("?  %s", text))
+		testStr += st.gray.Render(fmt.Sprintf("%s  %s", st.unknown, text))
 	} else if *passed {
-		testStr += green.Render(fmt.Sprintf("✓  %s", text))
+		testStr += st.green.Render(fmt.Sprintf("%s  %s", st.pass, text))
 	} else {
-		testStr += red.Render(fmt.Sprintf("X  %s", text))
+		testStr += st.red.Render(fmt.Sprintf("%s  %s", st.fail, text))
 	}
 	return testStr
 }
@@ -0,0 +1,124 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type deviceTickMsg time.Time
+
+type deviceDoneMsg struct {
+	creds *api.LoginResponse
+	err   error
+}
+
+type deviceModel struct {
+	st       styles
+	spinner  spinner.Model
+	code     api.DeviceCode
+	expires  time.Time
+	now      time.Time
+	wait     func() (*api.LoginResponse, error)
+	cancel   context.CancelFunc
+	creds    *api.LoginResponse
+	err      error
+	finished bool
+}
+
+func deviceTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return deviceTickMsg(t)
+	})
+}
+
+func (m deviceModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, deviceTick(), func() tea.Msg {
+		creds, err := m.wait()
+		return deviceDoneMsg{creds: creds, err: err}
+	})
+}
+
+func (m deviceModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case deviceDoneMsg:
+		m.creds, m.err = msg.creds, msg.err
+		m.finished = true
+		return m, tea.Quit
+	case deviceTickMsg:
+		m.now = time.Time(msg)
+		return m, deviceTick()
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" || msg.String() == "q" || msg.String() == "esc" {
+			// wait returns once its context is canceled
+			m.cancel()
+		}
+		return m, nil
+	default:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+}
+
+func (m deviceModel) View() string {
+	url := m.code.VerificationURI
+	str := "\n" + i18n.T("render.device.instructions", m.st.accent.Render(url)) + "\n\n"
+	str += m.st.borderBox.MarginLeft(2).Render(" "+m.st.accent.Render(m.code.UserCode)+" ") + "\n\n"
+	if m.code.VerificationURIComplete != "" {
+		str += m.st.gray.Render(i18n.T("render.device.direct_link", m.code.VerificationURIComplete)) + "\n\n"
+	}
+	if m.finished {
+		return str
+	}
+	remaining := max(0, m.expires.Sub(m.now).Round(time.Second))
+	countdown := fmt.Sprintf("%d:%02d", int(remaining.Minutes()), int(remaining.Seconds())%60)
+	str += m.spinner.View() + " " + i18n.T("render.device.waiting", countdown) + "\n"
+	str += m.st.gray.Render(i18n.T("render.device.cancel")) + "\n"
+	return str
+}
+
+// DeviceLogin shows the user code of a device login, where to enter it
+// and how long it's valid for, while wait polls for the login to be
+// approved. Quitting cancels the context passed to wait.
+func DeviceLogin(
+	ctx context.Context,
+	code api.DeviceCode,
+	wait func(ctx context.Context) (*api.LoginResponse, error),
+) (*api.LoginResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	st := currentStyles()
+	now := time.Now()
+	expires := now.Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	if Accessible() {
+		fmt.Println(i18n.T("render.device.instructions", code.VerificationURI))
+		fmt.Println(i18n.T("render.device.code", code.UserCode))
+		fmt.Println(i18n.T("render.device.waiting", expires.Sub(now).Round(time.Second)))
+		return wait(ctx)
+	}
+
+	s := spinner.New()
+	s.Spinner = st.spinner
+	m := deviceModel{
+		st:      st,
+		spinner: s,
+		code:    code,
+		expires: expires,
+		now:     now,
+		wait:    func() (*api.LoginResponse, error) { return wait(ctx) },
+		cancel:  cancel,
+	}
+	final, err := runProgram(newProgram(m))
+	if err != nil {
+		return nil, err
+	}
+	result := final.(deviceModel)
+	return result.creds, result.err
+}
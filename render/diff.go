@@ -0,0 +1,617 @@
+package render
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bootdotdev/bootdev/checks"
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/itchyny/gojq"
+	"github.com/muesli/reflow/truncate"
+)
+
+// DefaultDiffContext is how many unchanged lines are shown around each
+// difference unless the user asks for another number.
+const DefaultDiffContext = 3
+
+// the widest a single column of the side-by-side view is allowed to get
+const maxDiffColumnWidth = 60
+
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffRemoved
+	diffAdded
+	diffChanged
+)
+
+// diffRow is a single line of a side-by-side view. Expected is shown
+// on the left, actual on the right.
+type diffRow struct {
+	op       diffOp
+	expected string
+	actual   string
+}
+
+func (r diffRow) isChange() bool {
+	return r.op != diffEqual
+}
+
+// maxLCSCells caps the table lineDiff aligns lines with, at about 16 MB.
+// Longer inputs are compared line by line instead.
+const maxLCSCells = 1 << 22
+
+// lineDiff aligns two lists of lines using their longest common
+// subsequence, as determined by eq, and returns the rows of a
+// side-by-side view. Runs of removed lines directly followed by added
+// lines are paired up as changed rows.
+func lineDiff(expected, actual []string, eq func(expected, actual string) bool) []diffRow {
+	// the lines both start and end with need no alignment
+	prefix := 0
+	for prefix < len(expected) && prefix < len(actual) && eq(expected[prefix], actual[prefix]) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(expected)-prefix && suffix < len(actual)-prefix &&
+		eq(expected[len(expected)-1-suffix], actual[len(actual)-1-suffix]) {
+		suffix++
+	}
+
+	rows := []diffRow{}
+	for k := 0; k < prefix; k++ {
+		rows = append(rows, diffRow{op: diffEqual, expected: expected[k], actual: actual[k]})
+	}
+	rows = append(rows, alignLines(expected[prefix:len(expected)-suffix], actual[prefix:len(actual)-suffix], eq)...)
+	for k := suffix; k > 0; k-- {
+		rows = append(rows, diffRow{op: diffEqual, expected: expected[len(expected)-k], actual: actual[len(actual)-k]})
+	}
+	return rows
+}
+
+func alignLines(expected, actual []string, eq func(expected, actual string) bool) []diffRow {
+	n, m := len(expected), len(actual)
+	// lcs[i*(m+1)+j] is the length of the LCS of expected[i:] and actual[j:]
+	var lcs []int32
+	if n*m <= maxLCSCells {
+		lcs = make([]int32, (n+1)*(m+1))
+		for i := n - 1; i >= 0; i-- {
+			for j := m - 1; j >= 0; j-- {
+				if eq(expected[i], actual[j]) {
+					lcs[i*(m+1)+j] = lcs[(i+1)*(m+1)+j+1] + 1
+				} else {
+					lcs[i*(m+1)+j] = max(lcs[(i+1)*(m+1)+j], lcs[i*(m+1)+j+1])
+				}
+			}
+		}
+	}
+	// without a table, lines are removed and added in the order they come
+	addFirst := func(i, j int) bool {
+		if lcs == nil {
+			return false
+		}
+		return lcs[i*(m+1)+j+1] >= lcs[(i+1)*(m+1)+j]
+	}
+
+	rows := []diffRow{}
+	var removed, added []string
+	flush := func() {
+		for k := 0; k < max(len(removed), len(added)); k++ {
+			switch {
+			case k < len(removed) && k < len(added):
+				rows = append(rows, diffRow{op: diffChanged, expected: removed[k], actual: added[k]})
+			case k < len(removed):
+				rows = append(rows, diffRow{op: diffRemoved, expected: removed[k]})
+			default:
+				rows = append(rows, diffRow{op: diffAdded, actual: added[k]})
+			}
+		}
+		removed, added = nil, nil
+	}
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && eq(expected[i], actual[j]):
+			flush()
+			rows = append(rows, diffRow{op: diffEqual, expected: expected[i], actual: actual[j]})
+			i++
+			j++
+		case i < n && j < m && lcs == nil:
+			// compared by position, so a changed line is a changed row
+			flush()
+			rows = append(rows, diffRow{op: diffChanged, expected: expected[i], actual: actual[j]})
+			i++
+			j++
+		case j < m && (i == n || addFirst(i, j)):
+			added = append(added, actual[j])
+			j++
+		default:
+			removed = append(removed, expected[i])
+			i++
+		}
+	}
+	flush()
+	return rows
+}
+
+func exactMatch(a, b string) bool {
+	return a == b
+}
+
+// fitColumn pads or truncates text to exactly width cells of the
+// terminal, so that wide characters keep the columns aligned.
+func fitColumn(text string, width int) string {
+	if lipgloss.Width(text) > width {
+		text = truncate.StringWithTail(text, uint(width), "…")
+	}
+	return text + strings.Repeat(" ", max(0, width-lipgloss.Width(text)))
+}
+
+// contextRows marks the rows that are within context rows of a change.
+func contextRows(rows []diffRow, context int) []bool {
+	context = max(0, context)
+	keep := make([]bool, len(rows))
+	for i, row := range rows {
+		if !row.isChange() {
+			continue
+		}
+		for k := max(0, i-context); k <= min(len(rows)-1, i+context); k++ {
+			keep[k] = true
+		}
+	}
+	return keep
+}
+
+// renderDiffSentences describes each row in words rather than columns,
+// for screen readers.
+func renderDiffSentences(rows []diffRow, keep []bool) string {
+	str := ""
+	skipped := 0
+	for i, row := range rows {
+		if !keep[i] {
+			skipped++
+			continue
+		}
+		if skipped > 0 {
+			str += "  " + i18n.T("render.diff.skipped", skipped) + "\n"
+			skipped = 0
+		}
+		switch row.op {
+		case diffEqual:
+			if row.actual != "" {
+				str += "  " + i18n.T("render.diff.unchanged", row.actual) + "\n"
+			} else {
+				str += "  " + i18n.T("render.diff.matched", row.expected) + "\n"
+			}
+		case diffRemoved:
+			str += "  " + i18n.T("render.diff.sentence_missing", row.expected) + "\n"
+		case diffAdded:
+			str += "  " + i18n.T("render.diff.sentence_unexpected", row.actual) + "\n"
+		case diffChanged:
+			str += "  " + i18n.T("render.diff.sentence_changed", row.expected, row.actual) + "\n"
+		}
+	}
+	if skipped > 0 {
+		str += "  " + i18n.T("render.diff.skipped", skipped) + "\n"
+	}
+	return str
+}
+
+// renderSideBySide renders rows as two columns, collapsing unchanged
+// rows that are more than context rows away from any change.
+func (st styles) renderSideBySide(rows []diffRow, context int) string {
+	keep := contextRows(rows, context)
+	if st.accessible {
+		return renderDiffSentences(rows, keep)
+	}
+	return st.renderColumns(rows, keep, i18n.T("render.diff.expected"), i18n.T("render.diff.actual"))
+}
+
+// renderColumns renders the kept rows side by side under the titles,
+// noting how many rows were left out in between.
+func (st styles) renderColumns(rows []diffRow, keep []bool, expectedTitle, actualTitle string) string {
+	width := lipgloss.Width(expectedTitle) + 2
+	for _, row := range rows {
+		width = max(width, lipgloss.Width(row.expected)+2)
+	}
+	width = min(width, maxDiffColumnWidth)
+
+	cell := func(marker string, text string) string {
+		return fitColumn(marker+text, width)
+	}
+
+	str := "  " + cell("  ", expectedTitle) + " │ " + "  " + actualTitle + "\n"
+	str += "  " + strings.Repeat("─", width) + "─┼─" + strings.Repeat("─", width) + "\n"
+	skipped := 0
+	for i, row := range rows {
+		if !keep[i] {
+			skipped++
+			continue
+		}
+		if skipped > 0 {
+			str += st.gray.Render("  ⋮ "+i18n.T("render.diff.collapsed", skipped)) + "\n"
+			skipped = 0
+		}
+		left := cell("  ", row.expected)
+		right := "  " + row.actual
+		switch row.op {
+		case diffRemoved:
+			left = st.green.Render(cell("- ", row.expected))
+			right = ""
+		case diffAdded:
+			left = cell("", "")
+			right = st.red.Render("+ " + row.actual)
+		case diffChanged:
+			left = st.green.Render(cell("- ", row.expected))
+			right = st.red.Render("+ " + row.actual)
+		}
+		str += "  " + left + " │ " + right + "\n"
+	}
+	if skipped > 0 {
+		str += st.gray.Render("  ⋮ "+i18n.T("render.diff.collapsed", skipped)) + "\n"
+	}
+	return str
+}
+
+// renderHeaderTable lists all response headers next to the expected one,
+// highlighting the header the failed test was looking for.
+func (st styles) renderHeaderTable(expected api.HTTPTestHeader, actual map[string]string) string {
+	keys := make([]string, 0, len(actual)+1)
+	for k := range actual {
+		keys = append(keys, k)
+	}
+	canonical := strings.ToLower(expected.Key)
+	found := false
+	for _, k := range keys {
+		if strings.ToLower(k) == canonical {
+			found = true
+		}
+	}
+	if !found {
+		keys = append(keys, expected.Key)
+	}
+	sort.Strings(keys)
+
+	if st.accessible {
+		str := ""
+		for _, k := range keys {
+			value, ok := actual[k]
+			if strings.ToLower(k) != canonical {
+				continue
+			}
+			if !ok {
+				value = i18n.T("render.diff.missing")
+			}
+			str += "  " + i18n.T("render.diff.sentence_header", k, expected.Value, value) + "\n"
+		}
+		return str
+	}
+
+	headerTitle, actualTitle := i18n.T("render.diff.header"), i18n.T("render.diff.actual")
+	expectedLabel := i18n.T("render.diff.expected_header")
+	width := max(lipgloss.Width(headerTitle), lipgloss.Width(expectedLabel))
+	for _, k := range keys {
+		width = max(width, lipgloss.Width(k))
+	}
+	width = min(width, maxDiffColumnWidth)
+	pad := func(s string) string {
+		return fitColumn(s, width)
+	}
+
+	str := fmt.Sprintf("  %s │ %s\n", pad(headerTitle), actualTitle)
+	str += "  " + strings.Repeat("─", width) + "─┼─" + strings.Repeat("─", width) + "\n"
+	for _, k := range keys {
+		value, ok := actual[k]
+		if strings.ToLower(k) != canonical {
+			str += fmt.Sprintf("  %s │ %s\n", pad(k), value)
+			continue
+		}
+		if !ok {
+			value = "(" + i18n.T("render.diff.missing") + ")"
+		}
+		str += "  " + st.red.Render(fmt.Sprintf("%s │ %s", pad(k), value)) + "\n"
+		str += "  " + st.green.Render(fmt.Sprintf("%s │ %s", pad(expectedLabel), i18n.T("render.diff.contains", expected.Value))) + "\n"
+	}
+	return str
+}
+
+// jsonChange describes a single leaf that differs between two
+// JSON documents.
+type jsonChange struct {
+	path     string
+	expected any
+	actual   any
+}
+
+// jsonDiff walks two decoded JSON documents and returns every path
+// at which they differ.
+func jsonDiff(path string, expected, actual any) []jsonChange {
+	switch e := expected.(type) {
+	case map[string]any:
+		a, ok := actual.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := map[string]struct{}{}
+		for k := range e {
+			keys[k] = struct{}{}
+		}
+		for k := range a {
+			keys[k] = struct{}{}
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		changes := []jsonChange{}
+		for _, k := range sorted {
+			changes = append(changes, jsonDiff(path+"."+k, e[k], a[k])...)
+		}
+		return changes
+	case []any:
+		a, ok := actual.([]any)
+		if !ok {
+			break
+		}
+		changes := []jsonChange{}
+		for i := 0; i < max(len(e), len(a)); i++ {
+			var ev, av any
+			if i < len(e) {
+				ev = e[i]
+			}
+			if i < len(a) {
+				av = a[i]
+			}
+			changes = append(changes, jsonDiff(fmt.Sprintf("%s[%d]", path, i), ev, av)...)
+		}
+		return changes
+	}
+	if fmt.Sprint(expected) == fmt.Sprint(actual) {
+		return nil
+	}
+	return []jsonChange{{path: path, expected: expected, actual: actual}}
+}
+
+func prettyJSONLines(v any) []string {
+	pretty, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return []string{fmt.Sprint(v)}
+	}
+	return strings.Split(string(pretty), "\n")
+}
+
+// expectedJSONBody returns a copy of the actual body with the value at
+// the tested path replaced by the value the test expected.
+func expectedJSONBody(test api.HTTPTestJSONValue, actual any) (any, error) {
+	var expected any
+	switch {
+	case test.IntValue != nil:
+		expected = *test.IntValue
+	case test.StringValue != nil:
+		expected = *test.StringValue
+	case test.BoolValue != nil:
+		expected = *test.BoolValue
+	}
+	if test.Operator == api.OpGreaterThan {
+		expected = fmt.Sprintf("> %v", expected)
+	}
+
+	query, err := gojq.Parse(fmt.Sprintf("setpath(path(%s); $expected)", test.Path))
+	if err != nil {
+		return nil, err
+	}
+	code, err := gojq.Compile(query, gojq.WithVariables([]string{"$expected"}))
+	if err != nil {
+		return nil, err
+	}
+	// round trip so gojq sees plain JSON values
+	normalized, err := json.Marshal(expected)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if err := json.Unmarshal(normalized, &value); err != nil {
+		return nil, err
+	}
+	iter := code.Run(actual, value)
+	v, ok := iter.Next()
+	if !ok {
+		return nil, errors.New(i18n.T("render.diff.no_value", test.Path))
+	}
+	if err, ok := v.(error); ok {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (st styles) renderJSONDiff(test api.HTTPTestJSONValue, body string, context int) string {
+	var actual any
+	if err := json.Unmarshal([]byte(body), &actual); err != nil {
+		return st.renderSideBySide([]diffRow{{
+			op:       diffChanged,
+			expected: fmt.Sprintf("%s (JSON)", test.Path),
+			actual:   i18n.T("render.diff.invalid_json"),
+		}}, context)
+	}
+	expected, err := expectedJSONBody(test, actual)
+	if err != nil {
+		return st.renderSideBySide([]diffRow{{
+			op:       diffChanged,
+			expected: test.Path,
+			actual:   err.Error(),
+		}}, context)
+	}
+	str := ""
+	for _, change := range jsonDiff("", expected, actual) {
+		path := change.path
+		if path == "" {
+			path = "."
+		}
+		if st.accessible {
+			str += "  " + i18n.T("render.diff.sentence_json", path, change.expected, change.actual) + "\n"
+			continue
+		}
+		str += fmt.Sprintf("  %s: %s → %s\n", path,
+			st.green.Render(fmt.Sprintf("%v", change.expected)),
+			st.red.Render(fmt.Sprintf("%v", change.actual)))
+	}
+	rows := lineDiff(prettyJSONLines(expected), prettyJSONLines(actual), exactMatch)
+	return str + "\n" + st.renderSideBySide(rows, context)
+}
+
+func bodyLines(body string) []string {
+	var parsed any
+	if err := json.Unmarshal([]byte(body), &parsed); err == nil {
+		return prettyJSONLines(parsed)
+	}
+	return strings.Split(body, "\n")
+}
+
+func (st styles) httpFailureDiff(
+	data api.LessonDataHTTPTests,
+	results []checks.HttpTestResult,
+	failure *api.HTTPTestValidationError,
+	context int,
+) string {
+	if failure == nil || failure.FailedRequestIndex == nil || failure.FailedTestIndex == nil {
+		return ""
+	}
+	reqIndex, testIndex := *failure.FailedRequestIndex, *failure.FailedTestIndex
+	if reqIndex < 0 || reqIndex >= len(data.HttpTests.Requests) || reqIndex >= len(results) {
+		return ""
+	}
+	tests := data.HttpTests.Requests[reqIndex].Tests
+	if testIndex < 0 || testIndex >= len(tests) {
+		return ""
+	}
+	test := tests[testIndex]
+	result := results[reqIndex]
+	if result.Err != "" {
+		return ""
+	}
+	var str string
+	switch {
+	case test.StatusCode != nil:
+		str = st.renderSideBySide([]diffRow{{
+			op:       diffChanged,
+			expected: i18n.T("render.diff.status", *test.StatusCode),
+			actual:   i18n.T("render.diff.status", result.StatusCode),
+		}}, context)
+	case test.HeadersContain != nil:
+		str = st.renderHeaderTable(*test.HeadersContain, result.Headers)
+	case test.JSONValue != nil:
+		str = st.renderJSONDiff(*test.JSONValue, result.BodyString, context)
+	case test.BodyContains != nil:
+		rows := []diffRow{{op: diffRemoved, expected: *test.BodyContains}}
+		for _, line := range bodyLines(result.BodyString) {
+			rows = append(rows, diffRow{op: diffEqual, actual: line})
+		}
+		str = st.renderSideBySide(rows, context)
+	default:
+		return ""
+	}
+	return "\n  " + i18n.T("render.diff.title") + "\n\n" + str + "\n"
+}
+
+// stdoutDiff lines up the expectations of a CLI test with the lines
+// of stdout they apply to.
+func stdoutDiff(test api.CLICommandTestCase, stdout string) []diffRow {
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	switch {
+	case test.StdoutContainsAll != nil:
+		contains := func(expected, actual string) bool {
+			return strings.Contains(actual, expected)
+		}
+		rows := []diffRow{}
+		for _, row := range lineDiff(test.StdoutContainsAll, lines, contains) {
+			if row.op == diffRemoved || row.op == diffChanged {
+				if strings.Contains(stdout, row.expected) {
+					// present, just not in the order we expected
+					rows = append(rows, diffRow{op: diffEqual, expected: row.expected + " (" + i18n.T("render.diff.found") + ")"})
+				} else {
+					rows = append(rows, diffRow{op: diffRemoved, expected: row.expected})
+				}
+			}
+			switch row.op {
+			case diffEqual:
+				rows = append(rows, row)
+			case diffAdded, diffChanged:
+				// extra output is fine, it's only context
+				rows = append(rows, diffRow{op: diffEqual, actual: row.actual})
+			}
+		}
+		return rows
+	case test.StdoutContainsNone != nil:
+		rows := make([]diffRow, 0, len(lines))
+		for _, line := range lines {
+			row := diffRow{op: diffEqual, actual: line}
+			for _, forbidden := range test.StdoutContainsNone {
+				if strings.Contains(line, forbidden) {
+					row = diffRow{op: diffChanged, expected: i18n.T("render.diff.not", forbidden), actual: line}
+					break
+				}
+			}
+			rows = append(rows, row)
+		}
+		return rows
+	case test.StdoutMatches != nil:
+		rows := []diffRow{}
+		re, err := regexp.Compile(*test.StdoutMatches)
+		if err != nil || !re.MatchString(stdout) {
+			rows = append(rows, diffRow{op: diffRemoved, expected: "/" + *test.StdoutMatches + "/"})
+		}
+		for _, line := range lines {
+			rows = append(rows, diffRow{op: diffEqual, actual: line})
+		}
+		return rows
+	case test.StdoutLinesGt != nil:
+		return []diffRow{{
+			op:       diffChanged,
+			expected: "> " + i18n.T("render.diff.lines", *test.StdoutLinesGt),
+			actual:   i18n.T("render.diff.lines", len(lines)),
+		}}
+	}
+	return nil
+}
+
+func (st styles) cmdFailureDiff(
+	data api.LessonDataCLICommand,
+	results []api.CLICommandResult,
+	failure *api.StructuredErrCLICommand,
+	context int,
+) string {
+	if failure == nil {
+		return ""
+	}
+	cmdIndex, testIndex := failure.FailedCommandIndex, failure.FailedTestIndex
+	if cmdIndex < 0 || cmdIndex >= len(data.CLICommandData.Commands) || cmdIndex >= len(results) {
+		return ""
+	}
+	tests := data.CLICommandData.Commands[cmdIndex].Tests
+	if testIndex < 0 || testIndex >= len(tests) {
+		return ""
+	}
+	test := tests[testIndex]
+	result := results[cmdIndex]
+	var rows []diffRow
+	if test.ExitCode != nil {
+		rows = []diffRow{{
+			op:       diffChanged,
+			expected: i18n.T("render.diff.exit_code", *test.ExitCode),
+			actual:   i18n.T("render.diff.exit_code", result.ExitCode),
+		}}
+	} else {
+		rows = stdoutDiff(test, result.Stdout)
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+	return "\n > " + i18n.T("render.diff.title") + "\n\n" + st.renderSideBySide(rows, context) + "\n"
+}
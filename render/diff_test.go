@@ -0,0 +1,209 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bootdotdev/bootdev/checks"
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The tests check messages in English, whatever the locale of the
+// environment they run in.
+func TestMain(m *testing.M) {
+	i18n.SetLocale("en")
+	os.Exit(m.Run())
+}
+
+func TestLineDiff(t *testing.T) {
+	expected := []string{"a", "b", "c", "d"}
+	actual := []string{"a", "x", "c", "d", "e"}
+
+	rows := lineDiff(expected, actual, exactMatch)
+
+	want := []diffRow{
+		{op: diffEqual, expected: "a", actual: "a"},
+		{op: diffChanged, expected: "b", actual: "x"},
+		{op: diffEqual, expected: "c", actual: "c"},
+		{op: diffEqual, expected: "d", actual: "d"},
+		{op: diffAdded, actual: "e"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("Expected %d rows, got %d: %v", len(want), len(rows), rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("Row %d: expected %v, got %v", i, want[i], rows[i])
+		}
+	}
+}
+
+func TestLineDiffLargeBody(t *testing.T) {
+	var expected, actual []string
+	for i := 0; i < 10000; i++ {
+		line := fmt.Sprintf(`  "field%d": %d,`, i, i)
+		expected = append(expected, line)
+		actual = append(actual, line)
+	}
+	actual[5000] = `  "field5000": -1,`
+
+	allocs := testing.AllocsPerRun(1, func() {
+		rows := lineDiff(expected, actual, exactMatch)
+		if len(rows) != len(expected) || rows[5000].op != diffChanged {
+			t.Fatalf("Expected one changed row among %d, got %d rows", len(expected), len(rows))
+		}
+	})
+	// the equal ends are walked without a table, so only the rows allocate
+	if allocs > 100 {
+		t.Errorf("Expected the equal ends to be trimmed, got %v allocations", allocs)
+	}
+}
+
+func TestLineDiffTooLargeToAlign(t *testing.T) {
+	var expected, actual []string
+	for i := 0; i < 3000; i++ {
+		expected = append(expected, fmt.Sprintf("expected %d", i))
+		actual = append(actual, fmt.Sprintf("actual %d", i))
+	}
+	actual = append(actual, "extra")
+
+	rows := lineDiff(expected, actual, exactMatch)
+
+	if len(rows) != len(actual) {
+		t.Fatalf("Expected %d rows, got %d", len(actual), len(rows))
+	}
+	if rows[0].op != diffChanged || rows[len(rows)-1].op != diffAdded {
+		t.Errorf("Expected lines compared by position, got %v ... %v", rows[0], rows[len(rows)-1])
+	}
+}
+
+func TestFitColumnWideCharacters(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+	}{
+		{"日本語", 10},
+		{"日本語のテキストです", 8},
+		{"plain", 8},
+	}
+	for _, tt := range tests {
+		if got := lipgloss.Width(fitColumn(tt.text, tt.width)); got != tt.width {
+			t.Errorf("fitColumn(%q, %d) is %d cells wide", tt.text, tt.width, got)
+		}
+	}
+}
+
+func TestJSONDiff(t *testing.T) {
+	expected := map[string]any{"name": "boots", "tags": []any{"a", "b"}}
+	actual := map[string]any{"name": "boots", "tags": []any{"a", "c"}, "extra": true}
+
+	changes := jsonDiff("", expected, actual)
+
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %v", changes)
+	}
+	if changes[0].path != ".extra" || changes[1].path != ".tags[1]" {
+		t.Errorf("Unexpected paths: %v", changes)
+	}
+}
+
+func TestExpectedJSONBody(t *testing.T) {
+	want := "bear"
+	test := api.HTTPTestJSONValue{Path: ".user.name", Operator: api.OpEquals, StringValue: &want}
+	actual := map[string]any{"user": map[string]any{"name": "boots", "id": 1.0}}
+
+	expected, err := expectedJSONBody(test, actual)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	changes := jsonDiff("", expected, actual)
+	if len(changes) != 1 || changes[0].path != ".user.name" || changes[0].expected != "bear" {
+		t.Errorf("Unexpected changes: %v", changes)
+	}
+}
+
+func TestRenderSideBySideCollapsesContext(t *testing.T) {
+	rows := []diffRow{}
+	for i := 0; i < 10; i++ {
+		rows = append(rows, diffRow{op: diffEqual, expected: "same", actual: "same"})
+	}
+	rows = append(rows, diffRow{op: diffChanged, expected: "want", actual: "got"})
+
+	out := newStyles(builtinThemes[DefaultTheme]).renderSideBySide(rows, 2)
+
+	if !strings.Contains(out, "8 unchanged line(s)") {
+		t.Errorf("Expected 8 collapsed lines, got:\n%s", out)
+	}
+	if strings.Count(out, "same") != 4 {
+		t.Errorf("Expected 2 context rows, got:\n%s", out)
+	}
+}
+
+func TestHTTPRunDiff(t *testing.T) {
+	var data api.LessonDataHTTPTests
+	lesson := `{"HttpTests": {"Requests": [{"Request": {"Method": "GET", "Path": "/users"}, "Tests": [{"StatusCode": 200}]}]}}`
+	if err := json.Unmarshal([]byte(lesson), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	a := HTTPRunResults{ID: 1, Data: data, Results: []checks.HttpTestResult{{
+		StatusCode: 200,
+		Headers:    map[string]string{"Date": "Mon", "Content-Type": "application/json"},
+		BodyString: `{"name": "boots", "id": 1}`,
+	}}}
+	b := HTTPRunResults{ID: 2, Data: data, Results: []checks.HttpTestResult{{
+		StatusCode: 500,
+		Headers:    map[string]string{"Date": "Tue", "Content-Type": "application/json"},
+		BodyString: `{"name": "bear", "id": 1}`,
+	}}}
+	st := newStyles(builtinThemes[DefaultTheme])
+
+	out := st.httpRunDiff(a, b, DefaultDiffContext)
+	for _, want := range []string{"Request 1: GET /users", "Status code", "Body .name", "Now fails", "0 test(s) now pass, 1 now fail."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Date") || strings.Contains(out, ".id") {
+		t.Errorf("Expected only what changed, got:\n%s", out)
+	}
+
+	if out := st.httpRunDiff(a, a, DefaultDiffContext); out != "No differences.\n" {
+		t.Errorf("Expected no differences, got:\n%s", out)
+	}
+}
+
+func TestHTTPRunDiffUsesRecordedVerdicts(t *testing.T) {
+	var data api.LessonDataHTTPTests
+	lesson := `{"HttpTests": {"Requests": [{"Request": {"Method": "GET", "Path": "/users"}, "Tests": [{"StatusCode": 200}, {"StatusCode": 201}]}]}}`
+	if err := json.Unmarshal([]byte(lesson), &data); err != nil {
+		t.Fatal(err)
+	}
+	results := []checks.HttpTestResult{{StatusCode: 200}}
+	zero := 0
+	// the API failed the first test, whatever evaluating it again says
+	failed := HTTPRunResults{ID: 1, Data: data, Results: results, Verdict: RunVerdict{
+		Passed: pointerToBool(false), FailedIndex: &zero, FailedTestIndex: &zero,
+	}}
+	passed := HTTPRunResults{ID: 2, Data: data, Results: results, Verdict: RunVerdict{Passed: pointerToBool(true)}}
+	run := HTTPRunResults{ID: 3, Data: data, Results: results}
+	st := newStyles(builtinThemes[DefaultTheme])
+
+	// the second test never ran in the failed submission, so it can't flip
+	out := st.httpRunDiff(failed, passed, DefaultDiffContext)
+	if !strings.Contains(out, "1 test(s) now pass, 0 now fail.") {
+		t.Errorf("Expected the first test to pass now, by the verdicts, got:\n%s", out)
+	}
+
+	// a run without a verdict is evaluated: the first test passes, the second fails
+	out = st.httpRunDiff(passed, run, DefaultDiffContext)
+	if !strings.Contains(out, "0 test(s) now pass, 1 now fail.") {
+		t.Errorf("Expected the second test to fail in the run, got:\n%s", out)
+	}
+}
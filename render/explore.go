@@ -0,0 +1,448 @@
+package render
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/bootdotdev/bootdev/checks"
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+type exploreItem struct {
+	title  string
+	passed *bool
+	tests  []testModel
+	// the full output shown in the viewport when the item is selected
+	body string
+	// what gets copied to the clipboard, e.g. a curl command
+	copyText  string
+	copyLabel string
+}
+
+type exploreModel struct {
+	st        styles
+	items     []exploreItem
+	isSubmit  bool
+	cursor    int
+	expanded  map[int]bool
+	viewport  viewport.Model
+	search    textinput.Model
+	searching bool
+	query     string
+	status    string
+	width     int
+	height    int
+	ready     bool
+}
+
+func initialModelExplore(st styles, items []exploreItem, isSubmit bool) exploreModel {
+	search := textinput.New()
+	search.Prompt = "/"
+	search.Placeholder = i18n.T("render.explore.search")
+	return exploreModel{
+		st:       st,
+		items:    items,
+		isSubmit: isSubmit,
+		expanded: map[int]bool{},
+		search:   search,
+	}
+}
+
+func (m exploreModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m exploreModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, 0)
+			m.ready = true
+		}
+		m.viewport.Width = msg.Width
+		m.resize()
+		m.refresh()
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		m.status = ""
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+				m.refresh()
+				m.viewport.GotoTop()
+			}
+		case "down", "j":
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+				m.refresh()
+				m.viewport.GotoTop()
+			}
+		case "enter", " ", "right", "left", "l", "h":
+			m.expanded[m.cursor] = !m.expanded[m.cursor]
+			m.resize()
+		case "pgdown", "f":
+			m.viewport.ViewDown()
+		case "pgup", "b":
+			m.viewport.ViewUp()
+		case "ctrl+d":
+			m.viewport.HalfViewDown()
+		case "ctrl+u":
+			m.viewport.HalfViewUp()
+		case "home", "g":
+			m.viewport.GotoTop()
+		case "end", "G":
+			m.viewport.GotoBottom()
+		case "/":
+			m.searching = true
+			m.search.SetValue("")
+			m.resize()
+			return m, m.search.Focus()
+		case "n":
+			m.findNext(1)
+		case "N":
+			m.findNext(-1)
+		case "c":
+			item := m.items[m.cursor]
+			if item.copyText != "" {
+				termenv.Copy(item.copyText)
+				m.status = i18n.T("render.explore.copied", item.copyLabel)
+			}
+		}
+		return m, nil
+
+	case tea.MouseMsg:
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m exploreModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.query = m.search.Value()
+		m.searching = false
+		m.search.Blur()
+		m.resize()
+		m.refresh()
+		if m.query != "" {
+			m.findNext(0)
+		}
+		return m, nil
+	case "esc", "ctrl+c":
+		m.searching = false
+		m.search.Blur()
+		m.resize()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	return m, cmd
+}
+
+// findNext scrolls to the next line that contains the search query,
+// moving on to the following items once the current one is exhausted.
+// A direction of 0 includes the current line.
+func (m *exploreModel) findNext(direction int) {
+	if m.query == "" {
+		return
+	}
+	needle := strings.ToLower(m.query)
+	start := m.viewport.YOffset + direction
+	if direction < 0 {
+		direction = -1
+	} else {
+		direction = 1
+	}
+	for n := 0; n <= len(m.items); n++ {
+		index := (m.cursor + n*direction + len(m.items)*2) % len(m.items)
+		lines := strings.Split(m.items[index].body, "\n")
+		if n > 0 {
+			start = 0
+			if direction < 0 {
+				start = len(lines) - 1
+			}
+		}
+		for i := start; i >= 0 && i < len(lines); i += direction {
+			if strings.Contains(strings.ToLower(lines[i]), needle) {
+				m.cursor = index
+				m.refresh()
+				m.viewport.SetYOffset(i)
+				return
+			}
+		}
+	}
+	m.status = i18n.T("render.explore.no_matches", m.query)
+}
+
+// resize gives the viewport whatever space the list and footer don't need.
+func (m *exploreModel) resize() {
+	if !m.ready {
+		return
+	}
+	used := lipgloss.Height(m.listView()) + lipgloss.Height(m.footerView()) + 1
+	m.viewport.Height = max(3, m.height-used)
+}
+
+// refresh loads the selected item into the viewport, highlighting
+// any search matches.
+func (m *exploreModel) refresh() {
+	if !m.ready || len(m.items) == 0 {
+		return
+	}
+	body := m.items[m.cursor].body
+	if m.query != "" {
+		body = highlightMatches(body, m.query)
+	}
+	m.viewport.SetContent(body)
+}
+
+func highlightMatches(text, query string) string {
+	highlight := lipgloss.NewStyle().Reverse(true)
+	lines := strings.Split(text, "\n")
+	needle := strings.ToLower(query)
+	for i, line := range lines {
+		lower := strings.ToLower(line)
+		if len(lower) != len(line) || !strings.Contains(lower, needle) {
+			continue
+		}
+		var b strings.Builder
+		for {
+			idx := strings.Index(lower, needle)
+			if idx < 0 {
+				b.WriteString(line)
+				break
+			}
+			b.WriteString(line[:idx])
+			b.WriteString(highlight.Render(line[idx : idx+len(needle)]))
+			line, lower = line[idx+len(needle):], lower[idx+len(needle):]
+		}
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m exploreModel) listView() string {
+	lines := []string{}
+	selected := 0
+	for i, item := range m.items {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+			selected = len(lines)
+		}
+		arrow := "▸"
+		if m.expanded[i] {
+			arrow = "▾"
+		}
+		title := m.st.renderTest(item.title, "", true, &m.isSubmit, item.passed)
+		lines = append(lines, fmt.Sprintf("%s%s %s", prefix, arrow, title))
+		if m.expanded[i] {
+			for _, test := range item.tests {
+				testStr := m.st.renderTest(test.text, "", true, nil, test.passed)
+				for j, line := range strings.Split(testStr, "\n") {
+					edge := m.st.branch + " "
+					if j > 0 {
+						edge = m.st.trunk + "  "
+					}
+					lines = append(lines, "     "+edge+line)
+				}
+			}
+		}
+	}
+
+	// keep the selected item visible if the list is too long
+	limit := max(3, m.height/2)
+	if len(lines) > limit {
+		start := min(max(0, selected-limit/2), len(lines)-limit)
+		lines = lines[start : start+limit]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m exploreModel) footerView() string {
+	if m.searching {
+		return m.search.View()
+	}
+	help := i18n.T("render.explore.help")
+	if m.status != "" {
+		return m.st.gray.Render(m.status) + "\n" + m.st.gray.Render(help)
+	}
+	return m.st.gray.Render(help)
+}
+
+func (m exploreModel) View() string {
+	if !m.ready {
+		return ""
+	}
+	separator := m.st.gray.Render(strings.Repeat("─", max(0, m.width)))
+	return m.listView() + "\n" + separator + "\n" + m.viewport.View() + "\n" + m.footerView()
+}
+
+func runExplorer(st styles, items []exploreItem, isSubmit bool) {
+	if len(items) == 0 {
+		return
+	}
+	if st.accessible {
+		fmt.Println(i18n.T("render.explore.accessible"))
+		return
+	}
+	p := tea.NewProgram(
+		initialModelExplore(st, items, isSubmit),
+		tea.WithAltScreen(),
+		tea.WithMouseCellMotion(),
+	)
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+// curlCommand renders an executed request as a copy-pasteable curl command.
+func curlCommand(result checks.HttpTestResult) string {
+	quote := func(s string) string {
+		return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+	}
+	parts := []string{"curl", "-X", result.RequestMethod}
+	keys := make([]string, 0, len(result.RequestHeaders))
+	for k := range result.RequestHeaders {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range result.RequestHeaders[k] {
+			parts = append(parts, "-H", quote(k+": "+v))
+		}
+	}
+	if result.RequestBody != "" {
+		parts = append(parts, "--data", quote(result.RequestBody))
+	}
+	parts = append(parts, quote(result.RequestURL))
+	return strings.Join(parts, " ")
+}
+
+// HTTPExplore opens an interactive browser over the results of an HTTP lesson.
+func HTTPExplore(
+	data api.LessonDataHTTPTests,
+	results []checks.HttpTestResult,
+	failure *api.HTTPTestValidationError,
+	isSubmit bool,
+	diffContext int,
+) {
+	st := currentStyles()
+	items := make([]exploreItem, 0, len(data.HttpTests.Requests))
+	for i, req := range data.HttpTests.Requests {
+		if i >= len(results) {
+			break
+		}
+		failedReq, failedTest := -1, -1
+		if failure != nil && failure.FailedRequestIndex != nil && failure.FailedTestIndex != nil {
+			failedReq, failedTest = *failure.FailedRequestIndex, *failure.FailedTestIndex
+		}
+		item := exploreItem{
+			title:     fmt.Sprintf("%s %s", req.Request.Method, req.Request.Path),
+			passed:    resultState(isSubmit, failure != nil, failedReq, i, -1, -1),
+			body:      printHTTPResult(results[i]),
+			copyText:  curlCommand(results[i]),
+			copyLabel: i18n.T("render.explore.curl_label"),
+		}
+		for j, test := range req.Tests {
+			item.tests = append(item.tests, testModel{
+				text:     prettyPrintHTTPTest(test),
+				passed:   resultState(isSubmit, failure != nil, failedReq, i, failedTest, j),
+				finished: true,
+			})
+		}
+		if failedReq == i {
+			item.body += st.httpFailureDiff(data, results, failure, diffContext)
+			if failure.ErrorMessage != nil {
+				item.body += st.red.Render("\n"+i18n.T("render.error", *failure.ErrorMessage)) + "\n"
+			}
+		}
+		items = append(items, item)
+	}
+	runExplorer(st, items, isSubmit)
+}
+
+// CommandExplore opens an interactive browser over the results of a CLI command lesson.
+func CommandExplore(
+	data api.LessonDataCLICommand,
+	results []api.CLICommandResult,
+	failure *api.StructuredErrCLICommand,
+	isSubmit bool,
+	diffContext int,
+) {
+	st := currentStyles()
+	items := make([]exploreItem, 0, len(data.CLICommandData.Commands))
+	for i, cmd := range data.CLICommandData.Commands {
+		if i >= len(results) {
+			break
+		}
+		failedCmd, failedTest := -1, -1
+		if failure != nil {
+			failedCmd, failedTest = failure.FailedCommandIndex, failure.FailedTestIndex
+		}
+		body := "> " + i18n.T("render.cmd.exit_code", results[i].ExitCode) + "\n"
+		body += "> " + i18n.T("render.cmd.stdout") + "\n\n"
+		for _, line := range strings.Split(checks.TranslateOutput(results[i].Stdout), "\n") {
+			body += st.gray.Render(line) + "\n"
+		}
+		item := exploreItem{
+			title:     results[i].FinalCommand,
+			passed:    resultState(isSubmit, failure != nil, failedCmd, i, -1, -1),
+			body:      body,
+			copyText:  results[i].FinalCommand,
+			copyLabel: i18n.T("render.explore.command_label"),
+		}
+		for j, test := range cmd.Tests {
+			item.tests = append(item.tests, testModel{
+				text:     prettyPrintCmd(test),
+				passed:   resultState(isSubmit, failure != nil, failedCmd, i, failedTest, j),
+				finished: true,
+			})
+		}
+		if failedCmd == i {
+			item.body += st.cmdFailureDiff(data, results, failure, diffContext)
+			item.body += st.red.Render("\n"+i18n.T("render.error", failure.ErrorMessage)) + "\n"
+		}
+		items = append(items, item)
+	}
+	runExplorer(st, items, isSubmit)
+}
+
+// resultState works out whether a request (test == -1) or one of its tests
+// passed, failed, or never ran, given where the submission failed.
+func resultState(isSubmit, failed bool, failedIndex, index, failedTest, test int) *bool {
+	if !isSubmit {
+		return nil
+	}
+	if !failed || index < failedIndex {
+		return pointerToBool(true)
+	}
+	if index > failedIndex {
+		return nil
+	}
+	if test < 0 || test == failedTest {
+		return pointerToBool(false)
+	}
+	if test < failedTest {
+		return pointerToBool(true)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package render
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/bootdotdev/bootdev/checks"
+)
+
+func TestCurlCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		result checks.HttpTestResult
+		want   string
+	}{
+		{
+			name:   "plain GET",
+			result: checks.HttpTestResult{RequestMethod: "GET", RequestURL: "http://localhost:8080/users"},
+			want:   `curl -X GET 'http://localhost:8080/users'`,
+		},
+		{
+			name: "headers sorted, each value on its own",
+			result: checks.HttpTestResult{
+				RequestMethod:  "GET",
+				RequestURL:     "http://localhost:8080/users",
+				RequestHeaders: http.Header{"X-Multi": {"1", "2"}, "Accept": {"application/json"}},
+			},
+			want: `curl -X GET -H 'Accept: application/json' -H 'X-Multi: 1' -H 'X-Multi: 2' 'http://localhost:8080/users'`,
+		},
+		{
+			name: "single quotes in the body",
+			result: checks.HttpTestResult{
+				RequestMethod: "POST",
+				RequestURL:    "http://localhost:8080/users",
+				RequestBody:   `{"name": "O'Brien"}`,
+			},
+			want: `curl -X POST --data '{"name": "O'\''Brien"}' 'http://localhost:8080/users'`,
+		},
+		{
+			name:   "shell characters in the URL",
+			result: checks.HttpTestResult{RequestMethod: "GET", RequestURL: "http://localhost:8080/search?q=$HOME&page=1"},
+			want:   `curl -X GET 'http://localhost:8080/search?q=$HOME&page=1'`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := curlCommand(tt.result); got != tt.want {
+				t.Errorf("curlCommand() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResultState(t *testing.T) {
+	pass, fail := pointerToBool(true), pointerToBool(false)
+	tests := []struct {
+		name               string
+		isSubmit, failed   bool
+		failedIndex, index int
+		failedTest, test   int
+		want               *bool
+	}{
+		{"run has no verdict", false, true, 1, 0, 0, -1, nil},
+		{"passed submission", true, false, -1, 2, -1, 0, pass},
+		{"request before the failed one", true, true, 1, 0, 0, -1, pass},
+		{"failed request", true, true, 1, 1, 0, -1, fail},
+		{"request after the failed one", true, true, 1, 2, 0, -1, nil},
+		{"test before the failed one", true, true, 1, 1, 2, 1, pass},
+		{"failed test", true, true, 1, 1, 2, 2, fail},
+		{"test after the failed one", true, true, 1, 1, 2, 3, nil},
+		{"test of a request before the failed one", true, true, 1, 0, 2, 3, pass},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resultState(tt.isSubmit, tt.failed, tt.failedIndex, tt.index, tt.failedTest, tt.test)
+			if describe(got) != describe(tt.want) {
+				t.Errorf("resultState() = %s, want %s", describe(got), describe(tt.want))
+			}
+		})
+	}
+}
+
+func describe(state *bool) string {
+	if state == nil {
+		return "not run"
+	}
+	return fmt.Sprintf("passed=%v", *state)
+}
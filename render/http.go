@@ -11,11 +11,10 @@ import (
 
 	"github.com/bootdotdev/bootdev/checks"
 	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/termenv"
-	"github.com/spf13/viper"
 )
 
 type doneHttpMsg struct {
@@ -31,11 +30,13 @@ type resolveHttpMsg struct {
 	index   int
 	passed  *bool
 	results *checks.HttpTestResult
+	diff    string
 }
 type httpReqModel struct {
 	request  string
 	passed   *bool
 	results  *checks.HttpTestResult
+	diff     string
 	finished bool
 	tests    []testModel
 }
@@ -43,27 +44,29 @@ type httpReqModel struct {
 type httpRootModel struct {
 	reqs      []httpReqModel
 	spinner   spinner.Model
+	st        styles
 	failure   *api.HTTPTestValidationError
 	isSubmit  bool
+	local     bool
 	success   bool
 	finalized bool
 	clear     bool
 }
 
-func initialModelHTTP(isSubmit bool) httpRootModel {
+func initialModelHTTP(isSubmit bool, local bool) httpRootModel {
+	st := currentStyles()
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = st.spinner
 	return httpRootModel{
 		spinner:  s,
+		st:       st,
 		isSubmit: isSubmit,
+		local:    local,
 		reqs:     []httpReqModel{},
 	}
 }
 
 func (m httpRootModel) Init() tea.Cmd {
-	green = lipgloss.NewStyle().Foreground(lipgloss.Color(viper.GetString("color.green")))
-	red = lipgloss.NewStyle().Foreground(lipgloss.Color(viper.GetString("color.red")))
-	gray = lipgloss.NewStyle().Foreground(lipgloss.Color(viper.GetString("color.gray")))
 	return m.spinner.Tick
 }
 
@@ -85,6 +88,7 @@ func (m httpRootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.reqs[msg.index].passed = msg.passed
 		m.reqs[msg.index].finished = true
 		m.reqs[msg.index].results = msg.results
+		m.reqs[msg.index].diff = msg.diff
 		return m, nil
 
 	case startTestMsg:
@@ -113,32 +117,53 @@ func (m httpRootModel) View() string {
 	s := m.spinner.View()
 	var str string
 	for _, req := range m.reqs {
-		str += renderTestHeader(req.request, m.spinner, req.finished, m.isSubmit, req.passed)
-		str += renderTests(req.tests, s)
+		str += m.st.renderTestHeader(req.request, m.spinner, req.finished, m.isSubmit, req.passed)
+		str += m.st.renderTests(req.tests, s)
 		if req.results != nil && m.finalized {
 			str += printHTTPResult(*req.results)
+			str += req.diff
+			if (req.passed != nil && !*req.passed) || req.results.Err != "" {
+				str += m.st.serverLogs(*req.results)
+			}
 		}
 	}
 	if m.failure != nil {
-		str += red.Render("\n\nError: "+*m.failure.ErrorMessage) + "\n\n"
+		str += m.st.red.Render("\n\n"+i18n.T("render.error", *m.failure.ErrorMessage)) + "\n\n"
 	} else if m.success {
-		str += "\n\n" + green.Render("All tests passed! 🎉") + "\n\n"
-		str += green.Render("Return to your browser to continue with the next lesson.") + "\n\n"
+		str += "\n\n" + m.st.green.Render(i18n.T("render.all_passed")) + "\n\n"
+		if m.local {
+			str += m.st.green.Render(i18n.T("render.local_passed")) + "\n\n"
+		} else {
+			str += m.st.green.Render(i18n.T("render.return_to_browser")) + "\n\n"
+		}
 	}
 	return str
 }
 
+// serverLogs shows what the server logged while handling the request,
+// if the CLI started it.
+func (st styles) serverLogs(result checks.HttpTestResult) string {
+	if result.ServerLogs == "" {
+		return ""
+	}
+	str := "  " + i18n.T("render.http.server_logs") + "\n"
+	for _, line := range strings.Split(result.ServerLogs, "\n") {
+		str += st.gray.Render("   | "+line) + "\n"
+	}
+	return str + "\n"
+}
+
 func printHTTPResult(result checks.HttpTestResult) string {
 	str := ""
 	if result.Err != "" {
-		str += fmt.Sprintf("  Err: %v\n", result.Err)
+		str += "  " + i18n.T("render.http.err", result.Err) + "\n"
 	} else {
-		str += "  Request Headers: \n"
+		str += "  " + i18n.T("render.http.request_headers") + "\n"
 		for k, v := range result.RequestHeaders {
 			str += fmt.Sprintf("   - %v: %v\n", k, v[0])
 		}
-		str += fmt.Sprintf("  Response Status Code: %v\n", result.StatusCode)
-		str += "  Response Body: \n"
+		str += "  " + i18n.T("render.http.status_code", result.StatusCode) + "\n"
+		str += "  " + i18n.T("render.http.body") + "\n"
 		unmarshalled := map[string]interface{}{}
 		bytes := []byte(result.BodyString)
 
@@ -154,7 +179,7 @@ func printHTTPResult(result checks.HttpTestResult) string {
 				str += result.BodyString
 			}
 		} else {
-			str += fmt.Sprintf("Binary %s file", contentType)
+			str += i18n.T("render.http.binary", contentType)
 		}
 	}
 	str += "\n"
@@ -165,15 +190,27 @@ func HTTPRun(
 	data api.LessonDataHTTPTests,
 	results []checks.HttpTestResult,
 ) {
-	httpRenderer(data, results, nil, false)
+	httpRenderer(data, results, nil, false, false, DefaultDiffContext)
 }
 
 func HTTPSubmission(
 	data api.LessonDataHTTPTests,
 	results []checks.HttpTestResult,
 	failure *api.HTTPTestValidationError,
+	diffContext int,
 ) {
-	httpRenderer(data, results, failure, true)
+	httpRenderer(data, results, failure, true, false, diffContext)
+}
+
+// HTTPLocal renders results that were checked locally, by a lesson
+// that isn't published and so can't be submitted.
+func HTTPLocal(
+	data api.LessonDataHTTPTests,
+	results []checks.HttpTestResult,
+	failure *api.HTTPTestValidationError,
+	diffContext int,
+) {
+	httpRenderer(data, results, failure, true, true, diffContext)
 }
 
 func httpRenderer(
@@ -181,21 +218,29 @@ func httpRenderer(
 	results []checks.HttpTestResult,
 	failure *api.HTTPTestValidationError,
 	isSubmit bool,
+	local bool,
+	diffContext int,
 ) {
+	if Accessible() {
+		accessibleHTTPRenderer(currentStyles(), data, results, failure, isSubmit, local, diffContext)
+		return
+	}
 	var wg sync.WaitGroup
 	ch := make(chan tea.Msg, 1)
-	p := tea.NewProgram(initialModelHTTP(isSubmit), tea.WithoutSignalHandler())
+	model := initialModelHTTP(isSubmit, local)
+	st := model.st
+	p := newProgram(model, tea.WithoutSignalHandler())
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		if model, err := p.Run(); err != nil {
+		if model, err := runProgram(p); err != nil {
 			fmt.Fprintln(os.Stderr, err)
 		} else if r, ok := model.(httpRootModel); ok {
 			r.clear = false
 			r.finalized = true
 			output := termenv.NewOutput(os.Stdout)
 			if _, err := output.WriteString(r.View()); err != nil {
-				fmt.Fprintf(os.Stderr, "Fehler beim Schreiben des Outputs: %v\n", err)
+				fmt.Fprintln(os.Stderr, i18n.T("render.write_error", err))
 				return
 			}
 
@@ -236,7 +281,12 @@ func httpRenderer(
 				if passed {
 					ch <- resolveHttpMsg{index: i, passed: pointerToBool(passed)}
 				} else {
-					ch <- resolveHttpMsg{index: i, passed: pointerToBool(passed), results: &results[i]}
+					ch <- resolveHttpMsg{
+						index:   i,
+						passed:  pointerToBool(passed),
+						results: &results[i],
+						diff:    st.httpFailureDiff(data, results, failure, diffContext),
+					}
 				}
 			}
 		}
@@ -249,13 +299,13 @@ func httpRenderer(
 
 func prettyPrintHTTPTest(test api.HTTPTest) string {
 	if test.StatusCode != nil {
-		return fmt.Sprintf("Expecting status code: %d", *test.StatusCode)
+		return i18n.T("render.http.expect_status", *test.StatusCode)
 	}
 	if test.BodyContains != nil {
-		return fmt.Sprintf("Expecting JSON body to contain: %s", *test.BodyContains)
+		return i18n.T("render.http.expect_body", *test.BodyContains)
 	}
 	if test.HeadersContain != nil {
-		return fmt.Sprintf("Expecting header to contain: '%s: %v'", test.HeadersContain.Key, test.HeadersContain.Value)
+		return i18n.T("render.http.expect_header", test.HeadersContain.Key, test.HeadersContain.Value)
 	}
 	if test.JSONValue != nil {
 		var val any
@@ -269,11 +319,11 @@ func prettyPrintHTTPTest(test api.HTTPTest) string {
 		}
 
 		if test.JSONValue.Operator == api.OpEquals {
-			op = "to be equal to"
+			op = i18n.T("render.http.op_eq")
 		} else if test.JSONValue.Operator == api.OpGreaterThan {
-			op = "to be greater than"
+			op = i18n.T("render.http.op_gt")
 		}
-		return fmt.Sprintf("Expecting JSON at %v %s %v", test.JSONValue.Path, op, val)
+		return i18n.T("render.http.expect_json", test.JSONValue.Path, op, val)
 	}
 	return ""
 }
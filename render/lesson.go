@@ -0,0 +1,39 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+)
+
+// LessonOutline prints the requests or commands of a lesson and what
+// their tests check, without running anything.
+func LessonOutline(lesson api.Lesson) {
+	st := currentStyles()
+	switch {
+	case lesson.Lesson.LessonDataHTTPTests != nil:
+		for i, req := range lesson.Lesson.LessonDataHTTPTests.HttpTests.Requests {
+			fmt.Println(i18n.T("render.outline.request", i+1, req.Request.Method, req.Request.Path))
+			for _, test := range req.Tests {
+				printTest(st, prettyPrintHTTPTest(test))
+			}
+		}
+	case lesson.Lesson.LessonDataCLICommand != nil:
+		for i, command := range lesson.Lesson.LessonDataCLICommand.CLICommandData.Commands {
+			fmt.Println(i18n.T("render.outline.command", i+1, command.Command))
+			for _, test := range command.Tests {
+				printTest(st, prettyPrintCmd(test))
+			}
+		}
+	}
+}
+
+// printTest renders each line on its own, lipgloss would pad the shorter
+// lines of a block to the width of the longest.
+func printTest(st styles, text string) {
+	for _, line := range strings.Split("  - "+text, "\n") {
+		fmt.Println(st.gray.Render(line))
+	}
+}
@@ -0,0 +1,211 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PickerItem is one of the choices of Pick.
+type PickerItem struct {
+	Title string
+	// Detail is shown dimmed next to the title, and isn't searched.
+	Detail string
+}
+
+// fuzzyScore reports whether the letters of query appear in text in the
+// same order, and how well they match. Letters that follow each other or
+// start words count for more.
+func fuzzyScore(query, text string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(text))
+	score, qi, last := 0, 0, -2
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+		score++
+		if ti == last+1 {
+			score += 3
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 2
+		}
+		last = ti
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	return score, true
+}
+
+// filterItems returns the indexes of the items that match query, best
+// matches first. Equally good matches keep their order.
+func filterItems(query string, items []PickerItem) []int {
+	matches := []int{}
+	scores := map[int]int{}
+	for i, item := range items {
+		if score, ok := fuzzyScore(query, item.Title); ok {
+			matches = append(matches, i)
+			scores[i] = score
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool {
+		return scores[matches[a]] > scores[matches[b]]
+	})
+	return matches
+}
+
+type pickerModel struct {
+	st      styles
+	title   string
+	items   []PickerItem
+	input   textinput.Model
+	matches []int
+	cursor  int
+	picked  int
+	height  int
+	// the list is cleared once the picker closes
+	done bool
+}
+
+func initialModelPicker(st styles, title string, items []PickerItem, selected int) pickerModel {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = i18n.T("render.picker.placeholder")
+	input.Focus()
+	m := pickerModel{st: st, title: title, items: items, input: input, picked: -1, height: 20}
+	m.matches = filterItems("", items)
+	m.cursor = max(0, min(selected, len(items)-1))
+	return m
+}
+
+func (m pickerModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			m.done = true
+			return m, tea.Quit
+		case "enter":
+			if len(m.matches) > 0 {
+				m.picked = m.matches[m.cursor]
+			}
+			m.done = true
+			return m, tea.Quit
+		case "up", "ctrl+p":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "ctrl+n":
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+			return m, nil
+		}
+	}
+	query := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != query {
+		m.matches = filterItems(m.input.Value(), m.items)
+		m.cursor = 0
+	}
+	return m, cmd
+}
+
+func (m pickerModel) View() string {
+	if m.done {
+		return ""
+	}
+	str := m.title + "\n" + m.input.View() + "\n\n"
+	if len(m.matches) == 0 {
+		return str + m.st.gray.Render("  "+i18n.T("render.picker.no_matches")) + "\n"
+	}
+	// keep the selected item visible if the list is too long
+	limit := max(3, m.height-5)
+	start := 0
+	if len(m.matches) > limit {
+		start = min(max(0, m.cursor-limit/2), len(m.matches)-limit)
+	}
+	for i := start; i < min(len(m.matches), start+limit); i++ {
+		item := m.items[m.matches[i]]
+		prefix := "  "
+		title := item.Title
+		if i == m.cursor {
+			prefix = "> "
+			title = m.st.accent.Render(title)
+		}
+		str += prefix + title
+		if item.Detail != "" {
+			str += "  " + m.st.gray.Render(item.Detail)
+		}
+		str += "\n"
+	}
+	return str + "\n" + m.st.gray.Render(i18n.T("render.picker.help")) + "\n"
+}
+
+// Pick lets the user choose one of the items by typing parts of its
+// title, starting at the selected one. It returns the index of the item,
+// or -1 if the user didn't choose any. Screen readers get a numbered
+// list to answer instead.
+func Pick(title string, items []PickerItem, selected int) (int, error) {
+	st := currentStyles()
+	if st.accessible {
+		return pickByNumber(os.Stdin, os.Stderr, title, items, selected), nil
+	}
+	model, err := runProgram(newProgram(initialModelPicker(st, title, items, selected), tea.WithOutput(os.Stderr)))
+	if err != nil {
+		return -1, err
+	}
+	return model.(pickerModel).picked, nil
+}
+
+// pickByNumber lists the items and reads the number of one from in. An
+// empty answer picks the selected item, 0 or the end of the input none.
+func pickByNumber(in io.Reader, out io.Writer, title string, items []PickerItem, selected int) int {
+	selected = max(0, min(selected, len(items)-1))
+	fmt.Fprintln(out, title)
+	for i, item := range items {
+		line := fmt.Sprintf("%d. %s", i+1, item.Title)
+		if item.Detail != "" {
+			line += ", " + item.Detail
+		}
+		fmt.Fprintln(out, line)
+	}
+	reader := bufio.NewReader(in)
+	for {
+		fmt.Fprint(out, i18n.T("render.picker.number", len(items), selected+1))
+		answer, err := reader.ReadString('\n')
+		answer = strings.TrimSpace(answer)
+		switch n, convErr := strconv.Atoi(answer); {
+		case answer == "" && err == nil:
+			return selected
+		case convErr == nil && n == 0:
+			return -1
+		case convErr == nil && n >= 1 && n <= len(items):
+			return n - 1
+		case err != nil:
+			return -1
+		}
+		fmt.Fprintln(out, i18n.T("render.picker.invalid_number", answer))
+	}
+}
@@ -0,0 +1,54 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilterItems(t *testing.T) {
+	items := []PickerItem{
+		{Title: "Learn HTTP Clients"},
+		{Title: "Learn Go"},
+		{Title: "Build a Web Server"},
+		{Title: "Learn Git"},
+		{Title: "Networks"},
+	}
+
+	matches := filterItems("lgo", items)
+	if len(matches) != 1 || matches[0] != 1 {
+		t.Errorf("Expected only 'Learn Go', got %v", matches)
+	}
+
+	// letters that start words count for more than letters elsewhere
+	matches = filterItems("ws", items)
+	if len(matches) != 2 || matches[0] != 2 || matches[1] != 4 {
+		t.Errorf("Expected 'Build a Web Server' first, got %v", matches)
+	}
+
+	if matches := filterItems("", items); len(matches) != len(items) || matches[0] != 0 || matches[4] != 4 {
+		t.Errorf("Expected every item in order without a query, got %v", matches)
+	}
+}
+
+func TestPickByNumber(t *testing.T) {
+	items := []PickerItem{{Title: "Intro"}, {Title: "Echo it"}, {Title: "Headers"}}
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{input: "\n", want: 1},
+		{input: "3\n", want: 2},
+		{input: "0\n", want: -1},
+		{input: "nope\n7\n\n", want: 1},
+		{input: "", want: -1},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		if got := pickByNumber(strings.NewReader(tt.input), &out, "Pick:", items, 1); got != tt.want {
+			t.Errorf("Input %q: expected %d, got %d", tt.input, tt.want, got)
+		}
+		if !strings.Contains(out.String(), "Enter for 2") {
+			t.Errorf("Expected the default to be shown, got:\n%s", out.String())
+		}
+	}
+}
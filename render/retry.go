@@ -0,0 +1,78 @@
+package render
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/bootdotdev/bootdev/i18n"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// running is the program drawing to the terminal, if any. Notices are
+// printed above it through the program then, since it'd draw over them
+// otherwise.
+var running struct {
+	sync.Mutex
+	p *tea.Program
+}
+
+// noticeMsg is a line to print above the running program.
+type noticeMsg string
+
+// noticeModel prints notices above the model it wraps.
+type noticeModel struct {
+	tea.Model
+}
+
+func (m noticeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if notice, ok := msg.(noticeMsg); ok {
+		return m, tea.Println(string(notice))
+	}
+	model, cmd := m.Model.Update(msg)
+	return noticeModel{model}, cmd
+}
+
+// newProgram creates a program for model that notices can be printed
+// above once it's run with runProgram.
+func newProgram(model tea.Model, opts ...tea.ProgramOption) *tea.Program {
+	return tea.NewProgram(noticeModel{model}, opts...)
+}
+
+// runProgram runs a program created with newProgram and returns the
+// final state of its model.
+func runProgram(p *tea.Program) (tea.Model, error) {
+	running.Lock()
+	running.p = p
+	running.Unlock()
+	defer func() {
+		running.Lock()
+		running.p = nil
+		running.Unlock()
+	}()
+	model, err := p.Run()
+	if m, ok := model.(noticeModel); ok {
+		model = m.Model
+	}
+	return model, err
+}
+
+// Retrying tells the user that a request failed and is sent again once
+// wait is over, so a rate limited run doesn't look like it hangs.
+func Retrying(attempt int, wait time.Duration, err error) {
+	st := currentStyles()
+	wait = max(wait.Round(time.Second), time.Second)
+	notice := i18n.T("render.retrying", err, wait, attempt+1)
+	running.Lock()
+	defer running.Unlock()
+	switch {
+	case running.p != nil:
+		// Send gives up once the program is done
+		running.p.Send(noticeMsg(st.gray.Render(notice)))
+	case st.accessible:
+		fmt.Fprintln(os.Stderr, notice)
+	default:
+		fmt.Fprintln(os.Stderr, st.gray.Render(notice))
+	}
+}
@@ -0,0 +1,71 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// quitModel quits once it's sent true.
+type quitModel struct{}
+
+func (quitModel) Init() tea.Cmd { return nil }
+
+func (m quitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg == true {
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (quitModel) View() string { return "running\n" }
+
+func TestRetryingPrintsAboveProgram(t *testing.T) {
+	var out bytes.Buffer
+	p := newProgram(quitModel{}, tea.WithInput(nil), tea.WithOutput(&out), tea.WithoutSignalHandler())
+	done := make(chan tea.Model)
+	go func() {
+		model, err := runProgram(p)
+		if err != nil {
+			t.Error(err)
+		}
+		done <- model
+	}()
+	// wait for the program to be the running one
+	for {
+		running.Lock()
+		started := running.p == p
+		running.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	Retrying(1, 2*time.Second, errors.New("too many requests"))
+	// printed lines go out with the next frame, which quitting skips
+	time.Sleep(200 * time.Millisecond)
+	p.Send(true)
+	if _, ok := (<-done).(quitModel); !ok {
+		t.Error("Expected the final model without the notice wrapper")
+	}
+	if !strings.Contains(out.String(), "too many requests, retrying in 2s (attempt 2)...") {
+		t.Errorf("Expected the notice printed through the program, got %q", out.String())
+	}
+
+	// once the program is gone, notices don't wait for it
+	finished := make(chan struct{})
+	go func() {
+		Retrying(2, time.Second, errors.New("too many requests"))
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Retrying blocked after the program ended")
+	}
+}
@@ -0,0 +1,332 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bootdotdev/bootdev/checks"
+	api "github.com/bootdotdev/bootdev/client"
+	"github.com/bootdotdev/bootdev/i18n"
+)
+
+// headers that differ on every run, so they'd only be noise
+var volatileHeaders = map[string]bool{
+	"Date": true,
+}
+
+// RunVerdict is the verdict a run was recorded with. Passed is nil for
+// runs without one, whose tests are evaluated again instead.
+// FailedIndex is the request or command that failed and FailedTestIndex
+// the test of it.
+type RunVerdict struct {
+	Passed          *bool
+	FailedIndex     *int
+	FailedTestIndex *int
+}
+
+// testPassed is whether test j of request or command i passed in a run
+// with verdict v, or what evaluate says for runs without a verdict. It's
+// nil for tests that didn't run because an earlier one failed.
+func (v RunVerdict) testPassed(i, j int, evaluate func() bool) *bool {
+	if v.Passed == nil {
+		return pointerToBool(evaluate())
+	}
+	failedIndex, failedTest := -1, -1
+	if v.FailedIndex != nil && v.FailedTestIndex != nil {
+		failedIndex, failedTest = *v.FailedIndex, *v.FailedTestIndex
+	}
+	return resultState(true, !*v.Passed, failedIndex, i, failedTest, j)
+}
+
+// HTTPRunResults is a past run of an HTTP lesson, to be compared with
+// another one.
+type HTTPRunResults struct {
+	ID      int
+	Data    api.LessonDataHTTPTests
+	Results []checks.HttpTestResult
+	Verdict RunVerdict
+}
+
+// CommandRunResults is a past run of a CLI lesson, to be compared with
+// another one.
+type CommandRunResults struct {
+	ID      int
+	Data    api.LessonDataCLICommand
+	Results []api.CLICommandResult
+	Verdict RunVerdict
+}
+
+// HTTPRunDiff prints how two runs of an HTTP lesson differ, request by
+// request, and which tests passed in one run but not in the other.
+// Changed lines are shown with diffContext unchanged lines around them.
+func HTTPRunDiff(a, b HTTPRunResults, diffContext int) {
+	fmt.Print(currentStyles().httpRunDiff(a, b, diffContext))
+}
+
+// CommandRunDiff prints how two runs of a CLI lesson differ, command by
+// command, and which tests passed in one run but not in the other.
+// Changed lines are shown with diffContext unchanged lines around them.
+func CommandRunDiff(a, b CommandRunResults, diffContext int) {
+	fmt.Print(currentStyles().commandRunDiff(a, b, diffContext))
+}
+
+// runDiff collects the differences between run a and run b.
+type runDiff struct {
+	st         styles
+	a, b       int
+	context    int
+	str        string
+	nowPassing int
+	nowFailing int
+}
+
+func (d *runDiff) line(text string) {
+	d.str += "  " + text + "\n"
+}
+
+// change notes a value that isn't the same in both runs.
+func (d *runDiff) change(label string, a, b any) {
+	if d.st.accessible {
+		d.line(i18n.T("render.rundiff.sentence_change", label, a, d.a, b, d.b))
+		return
+	}
+	d.line(fmt.Sprintf("%s: %s → %s", label, d.st.gray.Render(fmt.Sprint(a)), fmt.Sprint(b)))
+}
+
+// lines notes the lines that aren't the same in both runs, next to each
+// other.
+func (d *runDiff) lines(label string, a, b []string) {
+	rows := lineDiff(a, b, exactMatch)
+	changed := false
+	for _, row := range rows {
+		changed = changed || row.isChange()
+	}
+	if !changed {
+		return
+	}
+	d.line(label + ":")
+	keep := contextRows(rows, d.context)
+	if d.st.accessible {
+		d.str += d.sentences(rows, keep)
+		return
+	}
+	d.str += d.st.renderColumns(rows, keep, i18n.T("render.rundiff.run", d.a), i18n.T("render.rundiff.run", d.b))
+}
+
+// sentences describes the rows in words, for screen readers.
+func (d *runDiff) sentences(rows []diffRow, keep []bool) string {
+	str := ""
+	skipped := 0
+	for i, row := range rows {
+		if !keep[i] {
+			skipped++
+			continue
+		}
+		if skipped > 0 {
+			str += "  " + i18n.T("render.diff.skipped", skipped) + "\n"
+			skipped = 0
+		}
+		switch row.op {
+		case diffEqual:
+			str += "  " + i18n.T("render.diff.unchanged", row.actual) + "\n"
+		case diffRemoved:
+			str += "  " + i18n.T("render.rundiff.sentence_only", d.a, row.expected) + "\n"
+		case diffAdded:
+			str += "  " + i18n.T("render.rundiff.sentence_only", d.b, row.actual) + "\n"
+		case diffChanged:
+			str += "  " + i18n.T("render.rundiff.sentence_change", i18n.T("render.rundiff.line"), row.expected, d.a, row.actual, d.b) + "\n"
+		}
+	}
+	if skipped > 0 {
+		str += "  " + i18n.T("render.diff.skipped", skipped) + "\n"
+	}
+	return str
+}
+
+// flipped notes a test that passed in one run and failed in the other.
+// A test that didn't run in either of them isn't compared.
+func (d *runDiff) flipped(test string, passedA, passedB *bool) {
+	if passedA == nil || passedB == nil {
+		return
+	}
+	switch {
+	case !*passedA && *passedB:
+		d.nowPassing++
+		d.line(d.st.green.Render(d.st.pass + " " + i18n.T("render.rundiff.now_passes", test)))
+	case *passedA && !*passedB:
+		d.nowFailing++
+		d.line(d.st.red.Render(d.st.fail + " " + i18n.T("render.rundiff.now_fails", test)))
+	}
+}
+
+// section adds what was collected since start under a heading, or drops
+// the heading if nothing differs.
+func (d *runDiff) section(heading string, start int) {
+	if len(d.str) == start {
+		return
+	}
+	d.str = d.str[:start] + heading + "\n" + d.str[start:] + "\n"
+}
+
+func (d *runDiff) done() string {
+	if d.str == "" {
+		return i18n.T("render.rundiff.same") + "\n"
+	}
+	return d.str + i18n.T("render.rundiff.summary", d.nowPassing, d.nowFailing) + "\n"
+}
+
+func (st styles) httpRunDiff(a, b HTTPRunResults, context int) string {
+	d := &runDiff{st: st, a: a.ID, b: b.ID, context: context}
+	requestsA, requestsB := a.Data.HttpTests.Requests, b.Data.HttpTests.Requests
+	for i := 0; i < max(len(requestsA), len(requestsB)); i++ {
+		start := len(d.str)
+		var heading string
+		switch {
+		case i >= len(requestsA):
+			heading = i18n.T("render.rundiff.request", i+1, requestsB[i].Request.Method, requestsB[i].Request.Path)
+			d.line(i18n.T("render.rundiff.only_in", b.ID))
+		case i >= len(requestsB):
+			heading = i18n.T("render.rundiff.request", i+1, requestsA[i].Request.Method, requestsA[i].Request.Path)
+			d.line(i18n.T("render.rundiff.only_in", a.ID))
+		default:
+			heading = i18n.T("render.rundiff.request", i+1, requestsB[i].Request.Method, requestsB[i].Request.Path)
+			resultA, resultB := httpResultAt(a.Results, i), httpResultAt(b.Results, i)
+			d.httpResult(resultA, resultB)
+			testsA, testsB := requestsA[i].Tests, requestsB[i].Tests
+			for j := 0; j < min(len(testsA), len(testsB)); j++ {
+				passedA := a.Verdict.testPassed(i, j, func() bool { return checks.EvaluateHTTPTest(testsA[j], resultA) == "" })
+				passedB := b.Verdict.testPassed(i, j, func() bool { return checks.EvaluateHTTPTest(testsB[j], resultB) == "" })
+				d.flipped(prettyPrintHTTPTest(testsB[j]), passedA, passedB)
+			}
+		}
+		d.section(heading, start)
+	}
+	return d.done()
+}
+
+func httpResultAt(results []checks.HttpTestResult, i int) checks.HttpTestResult {
+	if i >= len(results) {
+		return checks.HttpTestResult{Err: i18n.T("checks.eval.no_result")}
+	}
+	return results[i]
+}
+
+func (d *runDiff) httpResult(a, b checks.HttpTestResult) {
+	none := i18n.T("render.rundiff.none")
+	if a.Err != b.Err {
+		d.change(i18n.T("render.rundiff.error"), orDefault(a.Err, none), orDefault(b.Err, none))
+	}
+	if a.Err != "" || b.Err != "" {
+		// without a response there's nothing else to compare
+		return
+	}
+	if a.StatusCode != b.StatusCode {
+		d.change(i18n.T("render.rundiff.status"), a.StatusCode, b.StatusCode)
+	}
+
+	keys := []string{}
+	for key := range a.Headers {
+		keys = append(keys, key)
+	}
+	for key := range b.Headers {
+		if _, ok := a.Headers[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		valueA, okA := a.Headers[key]
+		valueB, okB := b.Headers[key]
+		if volatileHeaders[key] || (okA == okB && valueA == valueB) {
+			continue
+		}
+		if !okA {
+			valueA = none
+		}
+		if !okB {
+			valueB = none
+		}
+		d.change(i18n.T("render.rundiff.header", key), valueA, valueB)
+	}
+
+	var jsonA, jsonB any
+	if json.Unmarshal([]byte(a.BodyString), &jsonA) == nil && json.Unmarshal([]byte(b.BodyString), &jsonB) == nil {
+		for _, change := range jsonDiff("", jsonA, jsonB) {
+			path := change.path
+			if path == "" {
+				path = "."
+			}
+			d.change(i18n.T("render.rundiff.body")+" "+path, orNone(change.expected), orNone(change.actual))
+		}
+		return
+	}
+	d.lines(i18n.T("render.rundiff.body"), bodyLines(a.BodyString), bodyLines(b.BodyString))
+}
+
+func (st styles) commandRunDiff(a, b CommandRunResults, context int) string {
+	d := &runDiff{st: st, a: a.ID, b: b.ID, context: context}
+	commandsA, commandsB := a.Data.CLICommandData.Commands, b.Data.CLICommandData.Commands
+	for i := 0; i < max(len(commandsA), len(commandsB)); i++ {
+		start := len(d.str)
+		var heading string
+		switch {
+		case i >= len(commandsA):
+			heading = i18n.T("render.rundiff.command", i+1, commandsB[i].Command)
+			d.line(i18n.T("render.rundiff.only_in", b.ID))
+		case i >= len(commandsB):
+			heading = i18n.T("render.rundiff.command", i+1, commandsA[i].Command)
+			d.line(i18n.T("render.rundiff.only_in", a.ID))
+		default:
+			resultA, resultB := commandResultAt(a.Results, i), commandResultAt(b.Results, i)
+			command := commandsB[i].Command
+			if resultB.FinalCommand != "" {
+				command = resultB.FinalCommand
+			}
+			heading = i18n.T("render.rundiff.command", i+1, command)
+			if resultA.ExitCode != resultB.ExitCode {
+				d.change(i18n.T("render.rundiff.exit_code"), resultA.ExitCode, resultB.ExitCode)
+			}
+			d.lines(i18n.T("render.rundiff.stdout"), stdoutLines(checks.TranslateOutput(resultA.Stdout)), stdoutLines(checks.TranslateOutput(resultB.Stdout)))
+			testsA, testsB := commandsA[i].Tests, commandsB[i].Tests
+			for j := 0; j < min(len(testsA), len(testsB)); j++ {
+				passedA := a.Verdict.testPassed(i, j, func() bool { return checks.EvaluateCLITest(testsA[j], resultA) == "" })
+				passedB := b.Verdict.testPassed(i, j, func() bool { return checks.EvaluateCLITest(testsB[j], resultB) == "" })
+				d.flipped(prettyPrintCmd(testsB[j]), passedA, passedB)
+			}
+		}
+		d.section(heading, start)
+	}
+	return d.done()
+}
+
+func commandResultAt(results []api.CLICommandResult, i int) api.CLICommandResult {
+	if i >= len(results) {
+		// compared as a command that failed to run
+		return api.CLICommandResult{ExitCode: -1}
+	}
+	return results[i]
+}
+
+func stdoutLines(stdout string) []string {
+	if stdout == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+}
+
+func orDefault(s string, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+// orNone stands in for a JSON value that is missing from one body.
+func orNone(v any) any {
+	if v == nil {
+		return i18n.T("render.rundiff.none")
+	}
+	return v
+}
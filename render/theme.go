@@ -0,0 +1,257 @@
+package render
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/bootdotdev/bootdev/profile"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
+)
+
+// DefaultTheme is used when no theme has been configured.
+const DefaultTheme = "dark"
+
+// Theme controls how results are drawn. Built-in themes can be
+// overridden, and new ones added, in the config file under themes.<name>.
+// Any field a configured theme leaves empty falls back to the built-in
+// theme of the same name, and then to the default theme.
+type Theme struct {
+	Colors struct {
+		Gray   string
+		Red    string
+		Green  string
+		Accent string
+	}
+	// one of rounded, normal, thick, double or hidden
+	Border string
+	// one of dot, line, minidot, jump, pulse, points, globe, moon, monkey, meter or hamburger
+	Spinner string
+	Symbols struct {
+		Pass    string
+		Fail    string
+		Unknown string
+	}
+}
+
+func newTheme(gray, red, green, accent, border, spinner, pass, fail, unknown string) Theme {
+	t := Theme{Border: border, Spinner: spinner}
+	t.Colors.Gray = gray
+	t.Colors.Red = red
+	t.Colors.Green = green
+	t.Colors.Accent = accent
+	t.Symbols.Pass = pass
+	t.Symbols.Fail = fail
+	t.Symbols.Unknown = unknown
+	return t
+}
+
+var builtinThemes = map[string]Theme{
+	"dark":            newTheme("8", "1", "2", "#7e88f7", "rounded", "dot", "✓", "X", "?"),
+	"light":           newTheme("244", "124", "28", "#3f48cc", "rounded", "dot", "✓", "X", "?"),
+	"high-contrast":   newTheme("15", "9", "10", "11", "thick", "line", "✔", "✘", "?"),
+	"colorblind-safe": newTheme("#999999", "#e69f00", "#0072b2", "#cc79a7", "rounded", "dot", "✓", "✗", "?"),
+	"monochrome":      newTheme("", "", "", "", "normal", "line", "+", "x", "?"),
+}
+
+var borders = map[string]lipgloss.Border{
+	"rounded": lipgloss.RoundedBorder(),
+	"normal":  lipgloss.NormalBorder(),
+	"thick":   lipgloss.ThickBorder(),
+	"double":  lipgloss.DoubleBorder(),
+	"hidden":  lipgloss.HiddenBorder(),
+}
+
+// the character used where the tree of tests leaves a header's border
+var borderConnectors = map[string]string{
+	"rounded": "┬",
+	"normal":  "┬",
+	"thick":   "┳",
+	"double":  "╦",
+	"hidden":  " ",
+}
+
+var spinners = map[string]spinner.Spinner{
+	"dot":       spinner.Dot,
+	"line":      spinner.Line,
+	"minidot":   spinner.MiniDot,
+	"jump":      spinner.Jump,
+	"pulse":     spinner.Pulse,
+	"points":    spinner.Points,
+	"globe":     spinner.Globe,
+	"moon":      spinner.Moon,
+	"monkey":    spinner.Monkey,
+	"meter":     spinner.Meter,
+	"hamburger": spinner.Hamburger,
+}
+
+// ThemeNames lists the built-in themes along with any defined in the config file.
+func ThemeNames() []string {
+	names := []string{}
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	for name := range viper.GetStringMap("themes") {
+		if _, ok := builtinThemes[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ActiveTheme returns the name of the configured theme.
+func ActiveTheme() string {
+	name := viper.GetString("theme")
+	if name == "" {
+		return DefaultTheme
+	}
+	return name
+}
+
+// LoadTheme resolves a theme by name, layering the config file over
+// the built-in definitions and the environment over the config file.
+func LoadTheme(name string) (Theme, error) {
+	builtin, isBuiltin := builtinThemes[name]
+	if !isBuiltin && !viper.IsSet("themes."+name) {
+		return Theme{}, errors.New(i18n.T("render.theme.unknown", name, strings.Join(ThemeNames(), ", ")))
+	}
+	fallback := builtinThemes[DefaultTheme]
+	get := func(key string, builtinValue string, fallbackValue string) string {
+		if value := viper.GetString("themes." + name + "." + key); value != "" {
+			return value
+		}
+		if isBuiltin {
+			return builtinValue
+		}
+		return fallbackValue
+	}
+
+	t := Theme{}
+	t.Colors.Gray = get("colors.gray", builtin.Colors.Gray, fallback.Colors.Gray)
+	t.Colors.Red = get("colors.red", builtin.Colors.Red, fallback.Colors.Red)
+	t.Colors.Green = get("colors.green", builtin.Colors.Green, fallback.Colors.Green)
+	t.Colors.Accent = get("colors.accent", builtin.Colors.Accent, fallback.Colors.Accent)
+	t.Border = get("border", builtin.Border, fallback.Border)
+	t.Spinner = get("spinner", builtin.Spinner, fallback.Spinner)
+	t.Symbols.Pass = get("symbols.pass", builtin.Symbols.Pass, fallback.Symbols.Pass)
+	t.Symbols.Fail = get("symbols.fail", builtin.Symbols.Fail, fallback.Symbols.Fail)
+	t.Symbols.Unknown = get("symbols.unknown", builtin.Symbols.Unknown, fallback.Symbols.Unknown)
+
+	if _, ok := borders[t.Border]; !ok {
+		return Theme{}, errors.New(i18n.T("render.theme.unknown_border", name, t.Border))
+	}
+	if _, ok := spinners[t.Spinner]; !ok {
+		return Theme{}, errors.New(i18n.T("render.theme.unknown_spinner", name, t.Spinner))
+	}
+	return t, nil
+}
+
+// styles are the lipgloss styles and glyphs a renderer draws with.
+type styles struct {
+	gray      lipgloss.Style
+	red       lipgloss.Style
+	green     lipgloss.Style
+	accent    lipgloss.Style
+	borderBox lipgloss.Style
+	connector string
+	// the edges of the tree of tests below a header
+	branch  string
+	trunk   string
+	spinner spinner.Spinner
+	pass    string
+	fail    string
+	unknown string
+	// spell everything out for screen readers
+	accessible bool
+}
+
+func newStyles(t Theme) styles {
+	color := func(c string) lipgloss.Style {
+		if c == "" {
+			return lipgloss.NewStyle()
+		}
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(c))
+	}
+	border := borders[t.Border]
+	return styles{
+		gray:      color(t.Colors.Gray),
+		red:       color(t.Colors.Red),
+		green:     color(t.Colors.Green),
+		accent:    color(t.Colors.Accent),
+		borderBox: lipgloss.NewStyle().Border(border),
+		connector: borderConnectors[t.Border],
+		branch:    border.MiddleLeft + border.Top,
+		trunk:     border.Left,
+		spinner:   spinners[t.Spinner],
+		pass:      t.Symbols.Pass,
+		fail:      t.Symbols.Fail,
+		unknown:   t.Symbols.Unknown,
+	}
+}
+
+// legacyColors are the defaults of the color.* keys before themes existed.
+// They were written into every config file, so they don't mean the user
+// picked them.
+var legacyColors = map[string]string{
+	"gray":  "8",
+	"red":   "1",
+	"green": "2",
+}
+
+// currentStyles builds the styles for the active theme. The color.* keys
+// of the active profile, set by 'bootdev configure --color-*', still take
+// precedence over the theme.
+func currentStyles() styles {
+	if Accessible() {
+		return accessibleStyles()
+	}
+	t, err := LoadTheme(ActiveTheme())
+	if err != nil {
+		t = builtinThemes[DefaultTheme]
+	}
+	override := func(name string, c *string) {
+		if value := viper.GetString(profile.Key("color." + name)); value != "" && value != legacyColors[name] {
+			*c = value
+		}
+	}
+	override("gray", &t.Colors.Gray)
+	override("red", &t.Colors.Red)
+	override("green", &t.Colors.Green)
+	return newStyles(t)
+}
+
+// PreviewTheme renders a sample result tree using the given theme.
+func PreviewTheme(name string) (string, error) {
+	t, err := LoadTheme(name)
+	if err != nil {
+		return "", err
+	}
+	st := newStyles(t)
+	s := spinner.New()
+	s.Spinner = st.spinner
+	isSubmit := true
+
+	str := st.accent.Render(i18n.T("render.theme.preview_title", name)) + "\n\n"
+	str += st.renderTestHeader("GET /api/users", s, true, isSubmit, pointerToBool(true))
+	str += st.renderTests([]testModel{
+		{text: i18n.T("render.http.expect_status", 200), finished: true, passed: pointerToBool(true)},
+		{text: i18n.T("render.http.expect_header", "Content-Type", "application/json"), finished: true, passed: pointerToBool(true)},
+	}, s.View())
+	str += st.renderTestHeader("POST /api/users", s, true, isSubmit, pointerToBool(false))
+	str += st.renderTests([]testModel{
+		{text: i18n.T("render.http.expect_status", 201), finished: true, passed: pointerToBool(true)},
+		{text: i18n.T("render.http.expect_json", ".name", i18n.T("render.http.op_eq"), "boots"), finished: true, passed: pointerToBool(false)},
+		{text: i18n.T("render.http.expect_json", ".id", i18n.T("render.http.op_gt"), 0), finished: true},
+	}, s.View())
+	str += st.renderTestHeader("DELETE /api/users/1", s, false, isSubmit, nil)
+	str += st.renderTests([]testModel{
+		{text: i18n.T("render.http.expect_status", 204)},
+	}, s.View())
+	str += "  " + st.gray.Render(i18n.T("render.theme.gray")) + "  " + st.red.Render(i18n.T("render.theme.red")) +
+		"  " + st.green.Render(i18n.T("render.theme.green")) + "  " + st.accent.Render(i18n.T("render.theme.accent")) + "\n"
+	return str, nil
+}
@@ -0,0 +1,143 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
+)
+
+func TestLoadThemePrecedence(t *testing.T) {
+	config := `
+themes:
+  dark:
+    colors:
+      red: "#ff0000"
+      green: "#00ff00"
+  custom:
+    border: double
+`
+	tests := []struct {
+		name  string
+		theme string
+		env   map[string]string
+		check func(Theme) (got string, want string)
+	}{
+		{
+			name:  "built-in",
+			theme: "dark",
+			check: func(th Theme) (string, string) { return th.Colors.Gray, "8" },
+		},
+		{
+			name:  "file over built-in",
+			theme: "dark",
+			check: func(th Theme) (string, string) { return th.Colors.Red, "#ff0000" },
+		},
+		{
+			name:  "env over file",
+			theme: "dark",
+			env:   map[string]string{"BD_THEMES_DARK_COLORS_GREEN": "#0000ff"},
+			check: func(th Theme) (string, string) { return th.Colors.Green, "#0000ff" },
+		},
+		{
+			name:  "env over built-in",
+			theme: "dark",
+			env:   map[string]string{"BD_THEMES_DARK_SYMBOLS_PASS": "ok"},
+			check: func(th Theme) (string, string) { return th.Symbols.Pass, "ok" },
+		},
+		{
+			name:  "new theme falls back to the default one",
+			theme: "custom",
+			check: func(th Theme) (string, string) { return th.Border + " " + th.Colors.Accent, "double #7e88f7" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			viper.SetConfigType("yaml")
+			if err := viper.ReadConfig(strings.NewReader(config)); err != nil {
+				t.Fatal(err)
+			}
+			viper.SetEnvPrefix("bd")
+			viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+			viper.AutomaticEnv()
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			th, err := LoadTheme(tt.theme)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := tt.check(th); got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadThemeInvalid(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("themes.broken.spinner", "nope")
+
+	if _, err := LoadTheme("broken"); err == nil {
+		t.Error("Expected an unknown spinner to be rejected")
+	}
+	if _, err := LoadTheme("missing"); err == nil {
+		t.Error("Expected an unknown theme to be rejected")
+	}
+}
+
+func TestCurrentStylesIgnoresLegacyColors(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	// what older versions wrote into every config file, plus one color
+	// the user actually picked
+	config := `
+theme: light
+color:
+  gray: "8"
+  red: "#123456"
+  green: "2"
+`
+	viper.SetConfigType("yaml")
+	if err := viper.ReadConfig(strings.NewReader(config)); err != nil {
+		t.Fatal(err)
+	}
+
+	st := currentStyles()
+	light := builtinThemes["light"]
+	tests := []struct {
+		name  string
+		style lipgloss.Style
+		want  string
+	}{
+		{"gray", st.gray, light.Colors.Gray},
+		{"red", st.red, "#123456"},
+		{"green", st.green, light.Colors.Green},
+	}
+	for _, tt := range tests {
+		if got := tt.style.GetForeground(); got != lipgloss.Color(tt.want) {
+			t.Errorf("%s is %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTreeEdgesFollowBorder(t *testing.T) {
+	tests := map[string]string{
+		"rounded": " ├─",
+		"thick":   " ┣━",
+		"double":  " ╠═",
+	}
+	for border, want := range tests {
+		theme := builtinThemes[DefaultTheme]
+		theme.Border = border
+		out := newStyles(theme).renderTests([]testModel{{text: "test", finished: true}}, "")
+		if !strings.HasPrefix(out, want) {
+			t.Errorf("%s border: expected the tree to start with %q, got %q", border, want, out)
+		}
+	}
+}
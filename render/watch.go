@@ -0,0 +1,34 @@
+package render
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bootdotdev/bootdev/i18n"
+	"github.com/muesli/termenv"
+)
+
+// Rerun clears the screen for the next run in watch mode and says which
+// files changed. Screen readers keep the previous runs, a separator
+// marks where the new one starts.
+func Rerun(changed []string) {
+	st := currentStyles()
+	if Accessible() {
+		fmt.Println()
+		fmt.Println("----")
+	} else {
+		output := termenv.NewOutput(os.Stdout)
+		output.ClearScreen()
+	}
+	fmt.Println(st.gray.Render(i18n.T("render.watch.changed", strings.Join(changed, ", "))))
+	fmt.Println()
+}
+
+// Watching tells the user that the run is done and the next one starts
+// once files change.
+func Watching(paths []string) {
+	st := currentStyles()
+	fmt.Println()
+	fmt.Println(st.gray.Render(i18n.T("render.watch.watching", strings.Join(paths, ", "))))
+}
@@ -2,6 +2,7 @@ package version
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/bootdotdev/bootdev/i18n"
 	"golang.org/x/mod/semver"
 )
 
@@ -24,6 +26,10 @@ var trustedProxies = []string{
 	// Add more trusted proxies here if necessary
 }
 
+// ErrUnreachable is why fetching the update info failed when none of
+// the proxies could be reached at all, e.g. because the user is offline.
+var ErrUnreachable = errors.New("couldn't reach any proxy")
+
 // VersionInfo holds information about the current and latest version
 type VersionInfo struct {
 	CurrentVersion   string
@@ -54,8 +60,8 @@ func FetchUpdateInfo(currentVersion string) VersionInfo {
 // PromptUpdateIfAvailable prints a message if an update is available
 func (v *VersionInfo) PromptUpdateIfAvailable() {
 	if v.IsOutdated {
-		fmt.Fprintln(os.Stderr, "A new version of the bootdev CLI is available!")
-		fmt.Fprintln(os.Stderr, "Please run the following command to update:")
+		fmt.Fprintln(os.Stderr, i18n.T("version.available"))
+		fmt.Fprintln(os.Stderr, i18n.T("version.run_upgrade"))
 		fmt.Fprintf(os.Stderr, "  bootdev upgrade\n\n")
 	}
 }
@@ -86,6 +92,7 @@ func getLatestVersion() (string, error) {
 		}
 	}
 
+	reached := false
 	for _, proxy := range trustedProxies {
 		proxy = strings.TrimSpace(proxy)
 		proxy = strings.TrimRight(proxy, "/")
@@ -104,6 +111,7 @@ func getLatestVersion() (string, error) {
 			continue
 		}
 		defer resp.Body.Close()
+		reached = true
 
 		if resp.StatusCode != http.StatusOK {
 			continue
@@ -122,7 +130,10 @@ func getLatestVersion() (string, error) {
 		return version.Version, nil
 	}
 
-	return "", fmt.Errorf("failed to fetch latest version")
+	if !reached {
+		return "", ErrUnreachable
+	}
+	return "", errors.New(i18n.T("version.fetch_error"))
 }
 
 // isValidURL checks if the URL is a well-formed URL
@@ -0,0 +1,141 @@
+// Package watch reports changes to the files under a set of paths. A
+// burst of changes, like an editor saving or a build writing its output,
+// is reported once, after it settled.
+package watch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDelay is how long changes have to settle before they're reported.
+const DefaultDelay = 300 * time.Millisecond
+
+// Watcher watches files and directories, including every directory
+// below the watched ones.
+type Watcher struct {
+	fs    *fsnotify.Watcher
+	delay time.Duration
+	// dirs are the watched directories whose entries all count, files the
+	// watched files, which are watched through their directory since
+	// editors often save by replacing the file.
+	dirs  map[string]bool
+	files map[string]bool
+}
+
+// New watches paths, each a file or a directory.
+func New(paths []string, delay time.Duration) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{fs: fsw, delay: delay, dirs: map[string]bool{}, files: map[string]bool{}}
+	for _, path := range paths {
+		if err := w.add(path); err != nil {
+			fsw.Close()
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// add watches path and, if it's a directory, every directory below it.
+// fsnotify only reports changes to a directory's direct entries.
+func (w *Watcher) add(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		w.files[filepath.Clean(path)] = true
+		return w.fs.Add(filepath.Dir(path))
+	}
+	return filepath.WalkDir(path, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if dir != path && ignored(dir) {
+			return filepath.SkipDir
+		}
+		w.dirs[filepath.Clean(dir)] = true
+		return w.fs.Add(dir)
+	})
+}
+
+// relevant is true for changes to the watched files and to the entries
+// of the watched directories, other than ignored ones.
+func (w *Watcher) relevant(path string) bool {
+	return w.files[path] || (w.dirs[filepath.Dir(path)] && !ignored(path))
+}
+
+// ignored is true for files no one edits by hand: those in hidden
+// directories like .git, dependencies, and editors' temporary files.
+func ignored(path string) bool {
+	name := filepath.Base(path)
+	return (strings.HasPrefix(name, ".") && name != "." && name != "..") ||
+		name == "node_modules" ||
+		strings.HasSuffix(name, "~") ||
+		strings.HasSuffix(name, ".swp") ||
+		strings.HasSuffix(name, ".swx")
+}
+
+// Wait blocks until files changed and no more changes followed for the
+// delay, and returns the changed files.
+func (w *Watcher) Wait(ctx context.Context) ([]string, error) {
+	changed := map[string]bool{}
+	// the timer only runs once something changed
+	timer := time.NewTimer(w.delay)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return nil, errors.New("watcher closed")
+			}
+			return nil, err
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return nil, errors.New("watcher closed")
+			}
+			// a file's directory is watched as it was given, like "."
+			name := filepath.Clean(event.Name)
+			if event.Op == fsnotify.Chmod || !w.relevant(name) {
+				continue
+			}
+			if event.Op.Has(fsnotify.Create) && !w.files[name] {
+				// new directories are watched too, errors just mean it's gone again
+				if info, err := os.Stat(name); err == nil && info.IsDir() {
+					w.add(name)
+				}
+			}
+			changed[name] = true
+			timer.Reset(w.delay)
+		case <-timer.C:
+			files := make([]string, 0, len(changed))
+			for file := range changed {
+				files = append(files, file)
+			}
+			sort.Strings(files)
+			return files, nil
+		}
+	}
+}
+
+// Close stops watching.
+func (w *Watcher) Close() error {
+	return w.fs.Close()
+}
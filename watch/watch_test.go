@@ -0,0 +1,109 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func wait(t *testing.T, w *Watcher) []string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	files, err := w.Wait(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	w, err := New([]string{dir}, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	// a burst of writes is reported once, changes to ignored files not at all
+	main := filepath.Join(dir, "sub", "main.go")
+	for i := 0; i < 3; i++ {
+		if err := os.WriteFile(main, []byte("package main"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".git", "index"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go~"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if files := wait(t, w); len(files) != 1 || files[0] != main {
+		t.Fatalf("Expected only %s to have changed, got %v", main, files)
+	}
+
+	// new directories are watched as well
+	if err := os.MkdirAll(filepath.Join(dir, "new"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	wait(t, w)
+	added := filepath.Join(dir, "new", "handler.go")
+	if err := os.WriteFile(added, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if files := wait(t, w); len(files) != 1 || files[0] != added {
+		t.Fatalf("Expected %s to have changed, got %v", added, files)
+	}
+}
+
+func TestWaitCanceled(t *testing.T) {
+	w, err := New([]string{t.TempDir()}, DefaultDelay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := w.Wait(ctx); err != context.Canceled {
+		t.Errorf("Expected the wait to be canceled, got %v", err)
+	}
+}
+
+func TestWatchFileReplaced(t *testing.T) {
+	dir := t.TempDir()
+	lesson := filepath.Join(dir, "lesson.yaml")
+	if err := os.WriteFile(lesson, []byte("v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	w, err := New([]string{lesson}, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	// editors save by writing a new file and renaming it over the old
+	// one, which must not end the watch; other files next to it don't count
+	for i := 0; i < 2; i++ {
+		saved := filepath.Join(dir, "lesson.yaml.tmp")
+		if err := os.WriteFile(saved, []byte("v2"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(saved, lesson); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if files := wait(t, w); len(files) != 1 || files[0] != lesson {
+			t.Fatalf("Save %d: expected only %s to have changed, got %v", i+1, lesson, files)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// Package xdg locates the directories the XDG base directory spec sets
+// aside for an application's cache and data, each with a bootdev
+// subdirectory. Unset or relative XDG_* variables are ignored, as the
+// spec asks, in favor of the defaults under the home directory.
+package xdg
+
+import (
+	"os"
+	"path/filepath"
+)
+
+const app = "bootdev"
+
+// CacheDir is where data that can be fetched again is kept, by default
+// ~/.cache/bootdev.
+func CacheDir() (string, error) {
+	return dir("XDG_CACHE_HOME", ".cache")
+}
+
+// DataDir is where data that can't be recreated is kept, by default
+// ~/.local/share/bootdev.
+func DataDir() (string, error) {
+	return dir("XDG_DATA_HOME", filepath.Join(".local", "share"))
+}
+
+func dir(env string, fallback string) (string, error) {
+	if base := os.Getenv(env); filepath.IsAbs(base) {
+		return filepath.Join(base, app), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, fallback, app), nil
+}